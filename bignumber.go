@@ -0,0 +1,261 @@
+package jsonparser
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// Number is the raw literal of a JSON number, it keeps every digit
+// so it can be converted without overflow or loss of precision
+type Number string
+
+// numberTypes are the targets decoded by decodeNumber
+var numberTypes = []reflect.Type{
+	reflect.TypeFor[Number](),
+	reflect.TypeFor[json.Number](),
+	reflect.TypeFor[big.Int](),
+	reflect.TypeFor[*big.Int](),
+	reflect.TypeFor[big.Float](),
+	reflect.TypeFor[*big.Float](),
+	reflect.TypeFor[big.Rat](),
+	reflect.TypeFor[*big.Rat](),
+	reflect.TypeFor[Decimal](),
+}
+
+func (n Number) String() string {
+	return string(n)
+}
+
+// Int64 returns the number as an int64, it fails if it is not an integer or it overflows
+func (n Number) Int64() (int64, error) {
+	return parseInt64([]byte(n), math.MaxInt64)
+}
+
+// Uint64 returns the number as an uint64, it fails if it is not a positive integer or it overflows
+func (n Number) Uint64() (uint64, error) {
+	return parseUint64([]byte(n))
+}
+
+// Float64 returns the nearest float64 to the number
+func (n Number) Float64() (float64, error) {
+	return parseFloat64([]byte(n))
+}
+
+// BigInt returns the number as a big.Int, exponents and zero fractions are accepted as long
+// as the value is an integer: 1e30 and 10.0 are valid, 1.5 is not
+func (n Number) BigInt() (*big.Int, error) {
+	if res, ok := new(big.Int).SetString(string(n), 10); ok {
+		return res, nil
+	}
+
+	rat, err := n.Rat()
+	if err != nil || !rat.IsInt() {
+		return nil, ERROR_INVALID_INTEGER
+	}
+
+	return new(big.Int).Set(rat.Num()), nil
+}
+
+// BigFloat returns the number as a big.Float, the precision is large enough to hold every digit
+func (n Number) BigFloat() (*big.Float, error) {
+	// log2(10) < 3.33 bits per digit
+	prec := uint(len(n))*4 + 64
+
+	res, _, err := big.ParseFloat(string(n), 10, prec, big.ToNearestEven)
+	if err != nil {
+		return nil, ERROR_INVALID_FLOAT
+	}
+
+	return res, nil
+}
+
+// Rat returns the exact value of the number as a fraction
+func (n Number) Rat() (*big.Rat, error) {
+	res, ok := new(big.Rat).SetString(string(n))
+	if !ok {
+		return nil, ERROR_INVALID_FLOAT
+	}
+
+	return res, nil
+}
+
+// API
+
+// GetNumber returns the number at the field path keeping its raw literal
+func GetNumber(json []byte, fields ...string) (Number, error) {
+	if len(json) == 0 {
+		return "", ERROR_INVALID_JSON
+	}
+
+	if len(fields) == 0 {
+		return "", ERROR_ARGUMENTS
+	}
+
+	pos, err := findValuePosWs(json, fields...)
+	if err != nil {
+		return "", err
+	}
+
+	if valueType(json, pos) != TYPE_NUMBER {
+		return "", ERROR_TYPE_MISMATCH
+	}
+
+	valueSlice, err := extractValue(json, pos)
+	if err != nil {
+		return "", err
+	}
+
+	if !isValidNumber(valueSlice) {
+		return "", ERROR_INVALID_FLOAT
+	}
+
+	return Number(valueSlice), nil
+}
+
+// INTERNAL
+
+// parseUint64 parses a base 10 unsigned integer directly from the bytes
+func parseUint64(integer []byte) (uint64, error) {
+	if len(integer) == 0 {
+		return 0, ERROR_INVALID_INTEGER
+	}
+
+	var n uint64
+	for _, c := range integer {
+		digit := uint64(c - '0')
+		if digit > 9 {
+			return 0, ERROR_INVALID_INTEGER
+		}
+
+		if n > (math.MaxUint64-digit)/10 {
+			return 0, ERROR_INTEGER_OVERFLOW
+		}
+		n = n*10 + digit
+	}
+
+	return n, nil
+}
+
+// isValidNumber checks the number follows the JSON grammar:
+// -?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?
+func isValidNumber(num []byte) bool {
+	pos := 0
+	if pos < len(num) && num[pos] == '-' {
+		pos++
+	}
+
+	if pos >= len(num) {
+		return false
+	}
+
+	if num[pos] == '0' {
+		pos++
+	} else {
+		start := pos
+		for pos < len(num) && num[pos] >= '0' && num[pos] <= '9' {
+			pos++
+		}
+		if pos == start {
+			return false
+		}
+	}
+
+	if pos < len(num) && num[pos] == '.' {
+		pos++
+		start := pos
+		for pos < len(num) && num[pos] >= '0' && num[pos] <= '9' {
+			pos++
+		}
+		if pos == start {
+			return false
+		}
+	}
+
+	if pos < len(num) && (num[pos] == 'e' || num[pos] == 'E') {
+		pos++
+		if pos < len(num) && (num[pos] == '+' || num[pos] == '-') {
+			pos++
+		}
+		start := pos
+		for pos < len(num) && num[pos] >= '0' && num[pos] <= '9' {
+			pos++
+		}
+		if pos == start {
+			return false
+		}
+	}
+
+	return pos == len(num)
+}
+
+// decodeNumber stores a number into the arbitrary precision and Decimal targets
+func decodeNumber(value any, kind ValueType, slice []byte) (bool, error) {
+	switch value.(type) {
+	case *Number, *json.Number, *big.Int, **big.Int, *big.Float, **big.Float, *big.Rat, **big.Rat, *Decimal:
+	default:
+		return false, nil
+	}
+
+	if kind != TYPE_NUMBER {
+		return true, ERROR_TYPE_MISMATCH
+	}
+
+	if !isValidNumber(slice) {
+		return true, ERROR_INVALID_FLOAT
+	}
+
+	n := Number(slice)
+
+	switch target := value.(type) {
+	case *Number:
+		*target = n
+	case *json.Number:
+		*target = json.Number(n)
+	case *big.Int:
+		res, err := n.BigInt()
+		if err != nil {
+			return true, err
+		}
+		target.Set(res)
+	case **big.Int:
+		res, err := n.BigInt()
+		if err != nil {
+			return true, err
+		}
+		*target = res
+	case *big.Float:
+		res, err := n.BigFloat()
+		if err != nil {
+			return true, err
+		}
+		target.Set(res)
+	case **big.Float:
+		res, err := n.BigFloat()
+		if err != nil {
+			return true, err
+		}
+		*target = res
+	case *big.Rat:
+		res, err := n.Rat()
+		if err != nil {
+			return true, err
+		}
+		target.Set(res)
+	case **big.Rat:
+		res, err := n.Rat()
+		if err != nil {
+			return true, err
+		}
+		*target = res
+	case *Decimal:
+		res, err := ParseDecimal(slice)
+		if err != nil {
+			return true, err
+		}
+		*target = res
+	}
+
+	return true, nil
+}
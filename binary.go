@@ -0,0 +1,165 @@
+package jsonparser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// API
+
+// GetBytes decodes the base64 string at the field path.
+// Both the standard and the URL alphabets are accepted, padded or not. Null returns nil
+func GetBytes(json []byte, fields ...string) ([]byte, error) {
+	return AppendBytes(nil, json, fields...)
+}
+
+// AppendBytes decodes the base64 string at the field path and appends it to dst,
+// the string is decoded directly from json without intermediate copies
+func AppendBytes(dst []byte, json []byte, fields ...string) ([]byte, error) {
+	valueSlice, kind, err := lookupBinary(json, fields...)
+	if err != nil || kind == TYPE_NULL {
+		return dst, err
+	}
+
+	encoding, err := detectBase64(valueSlice)
+	if err != nil {
+		return dst, err
+	}
+
+	return appendDecoded(dst, valueSlice, encoding.DecodedLen(len(valueSlice)), encoding.Decode)
+}
+
+// GetHex decodes the hexadecimal string at the field path, null returns nil
+func GetHex(json []byte, fields ...string) ([]byte, error) {
+	return AppendHex(nil, json, fields...)
+}
+
+// AppendHex decodes the hexadecimal string at the field path and appends it to dst
+func AppendHex(dst []byte, json []byte, fields ...string) ([]byte, error) {
+	valueSlice, kind, err := lookupBinary(json, fields...)
+	if err != nil || kind == TYPE_NULL {
+		return dst, err
+	}
+
+	return appendDecoded(dst, valueSlice, hex.DecodedLen(len(valueSlice)), hex.Decode)
+}
+
+// INTERNAL
+
+// lookupBinary returns the content of the string at the field path, JSON escapes of '/' removed
+func lookupBinary(json []byte, fields ...string) ([]byte, ValueType, error) {
+	if len(json) == 0 {
+		return nil, TYPE_UNKNOWN, ERROR_INVALID_JSON
+	}
+
+	if len(fields) == 0 {
+		return nil, TYPE_UNKNOWN, ERROR_ARGUMENTS
+	}
+
+	pos, err := findValuePosWs(json, fields...)
+	if err != nil {
+		return nil, TYPE_UNKNOWN, err
+	}
+
+	kind := valueType(json, pos)
+	switch kind {
+	case TYPE_NULL:
+		_, err := extractNull(json, pos)
+		return nil, kind, err
+	case TYPE_STRING:
+	default:
+		return nil, kind, ERROR_TYPE_MISMATCH
+	}
+
+	valueSlice, err := extractString(json, pos)
+	if err != nil {
+		return nil, kind, err
+	}
+
+	valueSlice, err = unescapeSlashes(valueSlice)
+	return valueSlice, kind, err
+}
+
+// unescapeSlashes removes the JSON escapes "\/" that some encoders write in base64 strings,
+// the slice is copied only if it contains escapes. Other escapes can not appear in base64 or hex
+func unescapeSlashes(slice []byte) ([]byte, error) {
+	if bytes.IndexByte(slice, '\\') < 0 {
+		return slice, nil
+	}
+
+	res := make([]byte, 0, len(slice))
+	for i := 0; i < len(slice); i++ {
+		if slice[i] == '\\' {
+			if i+1 >= len(slice) || slice[i+1] != '/' {
+				return nil, ERROR_INVALID_STRING
+			}
+			i++
+		}
+		res = append(res, slice[i])
+	}
+
+	return res, nil
+}
+
+// detectBase64 picks the alphabet and the padding from the encoded string
+func detectBase64(slice []byte) (*base64.Encoding, error) {
+	std := bytes.ContainsAny(slice, "+/")
+	url := bytes.ContainsAny(slice, "-_")
+	padded := len(slice) > 0 && slice[len(slice)-1] == '='
+
+	switch {
+	case std && url:
+		return nil, ERROR_INVALID_STRING
+	case url && padded:
+		return base64.URLEncoding, nil
+	case url:
+		return base64.RawURLEncoding, nil
+	case padded:
+		return base64.StdEncoding, nil
+	}
+	return base64.RawStdEncoding, nil
+}
+
+// appendDecoded grows dst by size bytes and decodes src into them
+func appendDecoded(dst, src []byte, size int, decode func(dst, src []byte) (int, error)) ([]byte, error) {
+	start := len(dst)
+	dst = append(dst, make([]byte, size)...)
+
+	n, err := decode(dst[start:], src)
+	if err != nil {
+		return dst[:start], ERROR_INVALID_STRING
+	}
+
+	return dst[:start+n], nil
+}
+
+// decodeBytes stores a base64 string into a []byte target, like encoding/json does
+func decodeBytes(value any, kind ValueType, slice []byte) (bool, error) {
+	target, ok := value.(*[]byte)
+	if !ok {
+		return false, nil
+	}
+
+	switch kind {
+	case TYPE_NULL:
+		*target = nil
+		return true, nil
+	case TYPE_STRING:
+	default:
+		return true, ERROR_TYPE_MISMATCH
+	}
+
+	slice, err := unescapeSlashes(slice)
+	if err != nil {
+		return true, err
+	}
+
+	res, err := appendDecoded(nil, slice, base64.StdEncoding.DecodedLen(len(slice)), base64.StdEncoding.Decode)
+	if err != nil {
+		return true, err
+	}
+
+	*target = res
+	return true, nil
+}
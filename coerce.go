@@ -0,0 +1,216 @@
+package jsonparser
+
+import (
+	"bytes"
+	"reflect"
+)
+
+// Coercion controls how the typed getters treat values of a different JSON type than requested
+type Coercion int
+
+const (
+	// COERCE_DEFAULT is the behavior of the package level getters: the raw content of the value is parsed,
+	// so quoted numbers and booleans are accepted and any scalar can be read as a string
+	COERCE_DEFAULT Coercion = iota
+	// COERCE_LOOSE also accepts the boolean spellings 1/0, yes/no, on/off in any case, quoted or not,
+	// and numbers surrounded by whitespace inside quotes
+	COERCE_LOOSE
+	// COERCE_STRICT rejects every value whose JSON type is not the requested one
+	COERCE_STRICT
+)
+
+// Options configures the typed getters, the zero value behaves like the package level functions
+type Options struct {
+	Coercion Coercion
+}
+
+var (
+	trueLiteral  = []byte("true")
+	falseLiteral = []byte("false")
+)
+
+// API
+
+// GetWith is Get with the given options
+func GetWith[T any](o Options, value *T, json []byte, fields ...string) (*T, error) {
+	pos, err := o.findValuePos(json, fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	kind := valueType(json, pos)
+	expected := expectedType(reflect.TypeFor[T]())
+	if o.Coercion == COERCE_STRICT && kind != TYPE_NULL {
+		// checked here since Scan converts strings, null leaves the scanner invalid
+		expected = scannedType(reflect.TypeFor[T](), expected)
+	}
+
+	valueSlice, err := o.extract(json, pos, expected)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := decode(value, kind, valueSlice); err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func (o Options) GetString(json []byte, fields ...string) (string, error) {
+	valueSlice, err := o.lookup(json, TYPE_STRING, fields...)
+	if err != nil {
+		return "", err
+	}
+
+	return string(valueSlice), nil
+}
+
+func (o Options) GetBool(json []byte, fields ...string) (bool, error) {
+	valueSlice, err := o.lookup(json, TYPE_BOOLEAN, fields...)
+	if err != nil {
+		return false, err
+	}
+
+	return ParseBool(valueSlice)
+}
+
+func (o Options) GetInt(json []byte, fields ...string) (int, error) {
+	valueSlice, err := o.lookup(json, TYPE_NUMBER, fields...)
+	if err != nil {
+		return 0, err
+	}
+
+	return ParseInt(valueSlice)
+}
+
+func (o Options) GetInt64(json []byte, fields ...string) (int64, error) {
+	valueSlice, err := o.lookup(json, TYPE_NUMBER, fields...)
+	if err != nil {
+		return 0, err
+	}
+
+	return ParseInt64(valueSlice)
+}
+
+func (o Options) GetFloat64(json []byte, fields ...string) (float64, error) {
+	valueSlice, err := o.lookup(json, TYPE_NUMBER, fields...)
+	if err != nil {
+		return 0, err
+	}
+
+	return ParseFloat64(valueSlice)
+}
+
+// ParseBool parses the raw content of a value as a boolean,
+// with COERCE_LOOSE it also accepts 1/0, yes/no and on/off in any case
+func (o Options) ParseBool(boolean []byte) (bool, error) {
+	if o.Coercion != COERCE_LOOSE {
+		return ParseBool(boolean)
+	}
+
+	boolean = bytes.TrimSpace(boolean)
+	switch {
+	case bytes.EqualFold(boolean, trueLiteral),
+		bytes.EqualFold(boolean, []byte("1")),
+		bytes.EqualFold(boolean, []byte("yes")),
+		bytes.EqualFold(boolean, []byte("on")):
+		return true, nil
+	case bytes.EqualFold(boolean, falseLiteral),
+		bytes.EqualFold(boolean, []byte("0")),
+		bytes.EqualFold(boolean, []byte("no")),
+		bytes.EqualFold(boolean, []byte("off")):
+		return false, nil
+	}
+
+	return false, ERROR_INVALID_BOOLEAN
+}
+
+// ParseInt parses the raw content of a value as an integer,
+// with COERCE_LOOSE the whitespace around the number is ignored
+func (o Options) ParseInt(integer []byte) (int, error) {
+	if o.Coercion == COERCE_LOOSE {
+		integer = bytes.TrimSpace(integer)
+	}
+
+	return ParseInt(integer)
+}
+
+// INTERNAL
+
+// findValuePos returns the position of the value at the field path, whitespace skipped
+func (o Options) findValuePos(json []byte, fields ...string) (int, error) {
+	if len(json) == 0 {
+		return -1, ERROR_INVALID_JSON
+	}
+
+	if len(fields) == 0 {
+		return -1, ERROR_ARGUMENTS
+	}
+
+	return findValuePosWs(json, fields...)
+}
+
+// scannedType returns the JSON type held by a sql.Null* scanner such as sql.NullInt64,
+// from the type of its value field. Any other type keeps expected
+func scannedType(t reflect.Type, expected ValueType) ValueType {
+	if !reflect.PointerTo(t).Implements(scannerType) || t.Kind() != reflect.Struct ||
+		t.NumField() != 2 || t.Field(1).Name != "Valid" {
+		return expected
+	}
+
+	switch field := t.Field(0).Type; field.Kind() {
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8:
+		return TYPE_NUMBER // sql.NullByte, sql.NullInt16 and sql.NullInt32
+	default:
+		return expectedType(field)
+	}
+}
+
+// lookup returns the value at the field path converted to the expected type
+func (o Options) lookup(json []byte, expected ValueType, fields ...string) ([]byte, error) {
+	pos, err := o.findValuePos(json, fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.extract(json, pos, expected)
+}
+
+// extract returns the value starting at pos, rewritten when needed so that the
+// Parse functions accept it as the expected type
+func (o Options) extract(json []byte, pos int, expected ValueType) ([]byte, error) {
+	actual := valueType(json, pos)
+
+	if o.Coercion == COERCE_STRICT && expected != TYPE_UNKNOWN && actual != expected {
+		return nil, ERROR_TYPE_MISMATCH
+	}
+
+	valueSlice, err := extractValue(json, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Coercion != COERCE_LOOSE {
+		return valueSlice, nil
+	}
+
+	switch expected {
+	case TYPE_BOOLEAN:
+		boolean, err := o.ParseBool(valueSlice)
+		if err != nil {
+			return nil, err
+		}
+		if boolean {
+			return trueLiteral, nil
+		}
+		return falseLiteral, nil
+
+	case TYPE_NUMBER:
+		if actual == TYPE_STRING {
+			return bytes.TrimSpace(valueSlice), nil
+		}
+	}
+
+	return valueSlice, nil
+}
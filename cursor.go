@@ -0,0 +1,229 @@
+package jsonparser
+
+// Cursor walks a document one step at a time. It points at a single value and keeps
+// the offsets of the containers it went through, so moving to a child, a sibling
+// or the parent never re-resolves a path from the start of the document
+type Cursor struct {
+	json  []byte
+	stack []cursorFrame // containers entered, the innermost last
+	start int           // current value, [start, end)
+	end   int
+	key   []byte // key of the current value in its object, nil in arrays and at the root
+	index int    // index of the current value in its array, -1 in objects and at the root
+	err   error
+}
+
+type cursorFrame struct {
+	start, end int
+	key        []byte
+	index      int
+}
+
+// API
+
+// NewCursor returns a cursor on the whole document
+func NewCursor(json []byte) (*Cursor, error) {
+	pos := skipWhitespace(json, 0)
+	if pos >= len(json) {
+		return nil, ERROR_INVALID_JSON
+	}
+
+	end, err := valueEnd(json, pos)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Cursor{json: json, start: pos, end: end, index: -1}, nil
+}
+
+// Down moves to the member key of the current object
+func (c *Cursor) Down(key string) error {
+	if valueType(c.json, c.start) != TYPE_OBJECT {
+		return ERROR_TYPE_MISMATCH
+	}
+
+	found := false
+	_, err := objectEach(c.json, c.start, func(k []byte, start, end int) error {
+		if string(k) != key {
+			return nil
+		}
+		c.push(start, end, k, -1)
+		found = true
+		return errStop
+	})
+
+	return c.moved(found, err)
+}
+
+// DownIndex moves to the i-th element of the current array
+func (c *Cursor) DownIndex(i int) error {
+	if valueType(c.json, c.start) != TYPE_ARRAY {
+		return ERROR_TYPE_MISMATCH
+	}
+
+	if i < 0 {
+		return ERROR_ARGUMENTS
+	}
+
+	found := false
+	_, err := arrayEach(c.json, c.start, func(index, start, end int) error {
+		if index != i {
+			return nil
+		}
+		c.push(start, end, nil, index)
+		found = true
+		return errStop
+	})
+
+	return c.moved(found, err)
+}
+
+// DownFirst moves to the first member or element of the current container,
+// it fails with ERROR_FIELD_NOT_FOUND when the container is empty
+func (c *Cursor) DownFirst() error {
+	found := false
+	stop := func(key []byte, index, start, end int) error {
+		c.push(start, end, key, index)
+		found = true
+		return errStop
+	}
+
+	var err error
+	switch valueType(c.json, c.start) {
+	case TYPE_OBJECT:
+		_, err = objectEach(c.json, c.start, func(key []byte, start, end int) error {
+			return stop(key, -1, start, end)
+		})
+	case TYPE_ARRAY:
+		_, err = arrayEach(c.json, c.start, func(index, start, end int) error {
+			return stop(nil, index, start, end)
+		})
+	default:
+		return ERROR_TYPE_MISMATCH
+	}
+
+	return c.moved(found, err)
+}
+
+// Next moves to the next sibling of the current value. It returns false at the end
+// of the container, at the root and on malformed input, Err tells the last case apart
+func (c *Cursor) Next() bool {
+	if len(c.stack) == 0 || c.err != nil {
+		return false
+	}
+
+	pos := skipWhitespace(c.json, c.end)
+	if pos >= len(c.json) {
+		c.err = ERROR_INVALID_JSON
+		return false
+	}
+
+	switch c.json[pos] {
+	case '}', ']':
+		return false
+	case ',':
+	default:
+		c.err = ERROR_INVALID_JSON
+		return false
+	}
+
+	pos = skipWhitespace(c.json, pos+1)
+
+	var key []byte
+	if c.key != nil {
+		if pos >= len(c.json) || c.json[pos] != '"' {
+			c.err = ERROR_INVALID_JSON
+			return false
+		}
+
+		var err error
+		if key, err = extractString(c.json, pos); err != nil {
+			c.err = err
+			return false
+		}
+
+		pos = skipWhitespace(c.json, pos+len(key)+2)
+		if pos >= len(c.json) || c.json[pos] != ':' {
+			c.err = ERROR_COLON_NOT_FOUND
+			return false
+		}
+		pos = skipWhitespace(c.json, pos+1)
+	}
+
+	end, err := valueEnd(c.json, pos)
+	if err != nil {
+		c.err = err
+		return false
+	}
+
+	c.start, c.end, c.key = pos, end, key
+	if c.index >= 0 {
+		c.index++
+	}
+
+	return true
+}
+
+// Up moves back to the container of the current value
+func (c *Cursor) Up() error {
+	if len(c.stack) == 0 {
+		return ERROR_ARGUMENTS
+	}
+
+	frame := c.stack[len(c.stack)-1]
+	c.stack = c.stack[:len(c.stack)-1]
+	c.start, c.end, c.key, c.index = frame.start, frame.end, frame.key, frame.index
+	c.err = nil
+
+	return nil
+}
+
+// Key returns the key of the current value, empty in arrays and at the root
+func (c *Cursor) Key() string {
+	return string(c.key)
+}
+
+// Index returns the index of the current value in its array, -1 in objects and at the root
+func (c *Cursor) Index() int {
+	return c.index
+}
+
+// Value returns the current value
+func (c *Cursor) Value() Value {
+	return valueAt(c.json, c.start, c.end)
+}
+
+// Offset returns the position of the current value in the document
+func (c *Cursor) Offset() int {
+	return c.start
+}
+
+// Depth returns the number of containers entered, 0 at the root
+func (c *Cursor) Depth() int {
+	return len(c.stack)
+}
+
+// Err returns the error that stopped Next, if any
+func (c *Cursor) Err() error {
+	return c.err
+}
+
+// INTERNAL
+
+// push enters the child [start, end) of the current value
+func (c *Cursor) push(start, end int, key []byte, index int) {
+	c.stack = append(c.stack, cursorFrame{start: c.start, end: c.end, key: c.key, index: c.index})
+	c.start, c.end, c.key, c.index = start, end, key, index
+	c.err = nil
+}
+
+// moved turns the result of a search among the children into the error of Down
+func (c *Cursor) moved(found bool, err error) error {
+	switch {
+	case found:
+		return nil
+	case err != nil:
+		return err
+	}
+	return ERROR_FIELD_NOT_FOUND
+}
@@ -0,0 +1,333 @@
+package jsonparser
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Decimal is an exact base 10 number, mantissa * 10^-scale, meant for monetary values.
+// The scale is the number of fractional digits and is kept as parsed: 19.90 has scale 2,
+// unless the trailing zeros of the fraction do not fit 19 digits, then they are all dropped
+type Decimal struct {
+	mantissa int64
+	scale    int
+}
+
+var int64pow10 = [...]int64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+// NewDecimal returns mantissa * 10^-scale, NewDecimal(1999, 2) is 19.99
+func NewDecimal(mantissa int64, scale int) Decimal {
+	return Decimal{mantissa: mantissa, scale: scale}
+}
+
+// ParseDecimal parses a JSON number, it fails if the digits do not fit an int64
+func ParseDecimal(num []byte) (Decimal, error) {
+	if !isValidNumber(num) {
+		return Decimal{}, ERROR_INVALID_FLOAT
+	}
+
+	mantissa, exp, neg, ok := parseDecimal(num)
+
+	limit := uint64(math.MaxInt64)
+	if neg {
+		limit++
+	}
+
+	if !ok || mantissa > limit {
+		return Decimal{}, ERROR_INTEGER_OVERFLOW
+	}
+
+	d := Decimal{mantissa: int64(mantissa), scale: -exp}
+	if neg {
+		d.mantissa = -d.mantissa
+	}
+
+	// the trailing zeros of the fraction are restored when they fit, so 0.20 keeps scale 2,
+	// and no negative scale from positive exponents, 1e3 is 1000
+	scale := d.scale
+	if mantissa != 0 {
+		scale += fractionZeros(num)
+	}
+	if res, err := d.rescale(max(scale, 0)); err == nil {
+		return res, nil
+	}
+	if exp > 0 {
+		return d.rescale(0)
+	}
+
+	return d, nil
+}
+
+func (d Decimal) Mantissa() int64 {
+	return d.mantissa
+}
+
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign returns -1, 0 or 1
+func (d Decimal) Sign() int {
+	switch {
+	case d.mantissa < 0:
+		return -1
+	case d.mantissa > 0:
+		return 1
+	}
+	return 0
+}
+
+func (d Decimal) Neg() Decimal {
+	return Decimal{mantissa: -d.mantissa, scale: d.scale}
+}
+
+// Add returns d + other, the result has the largest scale of the two
+func (d Decimal) Add(other Decimal) (Decimal, error) {
+	a, b, err := align(d, other)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	sum := a.mantissa + b.mantissa
+	if (sum > a.mantissa) != (b.mantissa > 0) {
+		return Decimal{}, ERROR_INTEGER_OVERFLOW
+	}
+
+	return Decimal{mantissa: sum, scale: a.scale}, nil
+}
+
+// Sub returns d - other, the result has the largest scale of the two
+func (d Decimal) Sub(other Decimal) (Decimal, error) {
+	if other.mantissa == math.MinInt64 {
+		return Decimal{}, ERROR_INTEGER_OVERFLOW
+	}
+
+	return d.Add(other.Neg())
+}
+
+// Mul returns d * other, the result scale is the sum of the scales
+func (d Decimal) Mul(other Decimal) (Decimal, error) {
+	product := d.mantissa * other.mantissa
+	if d.mantissa != 0 && (product/d.mantissa != other.mantissa ||
+		(d.mantissa == -1 && other.mantissa == math.MinInt64) ||
+		(other.mantissa == -1 && d.mantissa == math.MinInt64)) {
+		return Decimal{}, ERROR_INTEGER_OVERFLOW
+	}
+
+	return Decimal{mantissa: product, scale: d.scale + other.scale}, nil
+}
+
+// Round returns d rounded half away from zero to at most scale fractional digits
+func (d Decimal) Round(scale int) Decimal {
+	if scale >= d.scale {
+		return d
+	}
+
+	diff := d.scale - scale
+	if diff >= len(int64pow10) {
+		// |mantissa| < 10^19, only a 19 digits shift of a large mantissa can round up
+		if diff == len(int64pow10) && (d.mantissa >= 5e18 || d.mantissa <= -5e18) {
+			return Decimal{mantissa: int64(d.Sign()), scale: scale}
+		}
+		return Decimal{mantissa: 0, scale: scale}
+	}
+
+	p := int64pow10[diff]
+	q, r := d.mantissa/p, d.mantissa%p
+
+	if r < 0 {
+		r = -r
+	}
+	if r >= p-r {
+		q += int64(d.Sign())
+	}
+
+	return Decimal{mantissa: q, scale: scale}
+}
+
+// Cmp returns -1, 0 or 1 when d is less than, equal to or greater than other
+func (d Decimal) Cmp(other Decimal) int {
+	a, b, err := align(d, other)
+	if err != nil {
+		// too far apart to align in an int64
+		return d.Rat().Cmp(other.Rat())
+	}
+
+	switch {
+	case a.mantissa < b.mantissa:
+		return -1
+	case a.mantissa > b.mantissa:
+		return 1
+	}
+	return 0
+}
+
+// Equal reports whether d and other are the same number, whatever their scales
+func (d Decimal) Equal(other Decimal) bool {
+	return d.Cmp(other) == 0
+}
+
+// Rat returns the exact value of d as a fraction
+func (d Decimal) Rat() *big.Rat {
+	res := new(big.Rat).SetInt64(d.mantissa)
+	if d.scale == 0 {
+		return res
+	}
+
+	exp := big.NewInt(int64(abs(d.scale)))
+	pow := new(big.Int).Exp(big.NewInt(10), exp, nil)
+	if d.scale > 0 {
+		return res.Quo(res, new(big.Rat).SetInt(pow))
+	}
+	return res.Mul(res, new(big.Rat).SetInt(pow))
+}
+
+// Float64 returns the nearest float64 to d
+func (d Decimal) Float64() float64 {
+	f, _ := parseFloat64(d.AppendText(nil))
+	return f
+}
+
+func (d Decimal) String() string {
+	return string(d.AppendText(nil))
+}
+
+// AppendText appends the decimal representation of d to dst, with exactly scale fractional digits
+func (d Decimal) AppendText(dst []byte) []byte {
+	if d.mantissa < 0 {
+		dst = append(dst, '-')
+	}
+
+	// uint64 handles math.MinInt64
+	magnitude := uint64(d.mantissa)
+	if d.mantissa < 0 {
+		magnitude = -magnitude
+	}
+
+	digits := strconv.AppendUint(nil, magnitude, 10)
+
+	if d.scale <= 0 {
+		dst = append(dst, digits...)
+		if magnitude != 0 {
+			for i := 0; i < -d.scale; i++ {
+				dst = append(dst, '0')
+			}
+		}
+		return dst
+	}
+
+	if len(digits) <= d.scale {
+		dst = append(dst, '0', '.')
+		for i := len(digits); i < d.scale; i++ {
+			dst = append(dst, '0')
+		}
+		return append(dst, digits...)
+	}
+
+	integerPart := len(digits) - d.scale
+	dst = append(dst, digits[:integerPart]...)
+	dst = append(dst, '.')
+	return append(dst, digits[integerPart:]...)
+}
+
+// MarshalJSON writes d as a JSON number without float artifacts
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return d.AppendText(nil), nil
+}
+
+// UnmarshalJSON accepts a JSON number, also quoted
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	if len(data) >= 2 && data[0] == '"' && data[len(data)-1] == '"' {
+		data = data[1 : len(data)-1]
+	}
+
+	res, err := ParseDecimal(data)
+	if err != nil {
+		return err
+	}
+
+	*d = res
+	return nil
+}
+
+// API
+
+// GetDecimal returns the number at the field path as a Decimal
+func GetDecimal(json []byte, fields ...string) (Decimal, error) {
+	var res Decimal
+	if _, err := Get(&res, json, fields...); err != nil {
+		return Decimal{}, err
+	}
+
+	return res, nil
+}
+
+// INTERNAL
+
+// rescale returns d with the given scale, it fails when digits would be lost or the mantissa overflows
+func (d Decimal) rescale(scale int) (Decimal, error) {
+	if scale < d.scale {
+		return Decimal{}, ERROR_ARGUMENTS
+	}
+
+	diff := scale - d.scale
+	if d.mantissa == 0 || diff == 0 {
+		return Decimal{mantissa: d.mantissa, scale: scale}, nil
+	}
+
+	if diff >= len(int64pow10) {
+		return Decimal{}, ERROR_INTEGER_OVERFLOW
+	}
+
+	p := int64pow10[diff]
+	if d.mantissa > math.MaxInt64/p || d.mantissa < math.MinInt64/p {
+		return Decimal{}, ERROR_INTEGER_OVERFLOW
+	}
+
+	return Decimal{mantissa: d.mantissa * p, scale: scale}, nil
+}
+
+// fractionZeros returns the number of trailing zeros of the fraction of a valid number
+func fractionZeros(num []byte) int {
+	end := bytes.IndexAny(num, "eE")
+	if end < 0 {
+		end = len(num)
+	}
+
+	dot := bytes.IndexByte(num[:end], '.')
+	if dot < 0 {
+		return 0
+	}
+
+	zeros := 0
+	for i := end - 1; i > dot && num[i] == '0'; i-- {
+		zeros++
+	}
+	return zeros
+}
+
+// align rescales a and b to the same scale
+func align(a, b Decimal) (Decimal, Decimal, error) {
+	var err error
+
+	switch {
+	case a.scale < b.scale:
+		a, err = a.rescale(b.scale)
+	case a.scale > b.scale:
+		b, err = b.rescale(a.scale)
+	}
+
+	return a, b, err
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,148 @@
+package jsonparser
+
+import (
+	"database/sql"
+	"reflect"
+	"slices"
+	"time"
+)
+
+var (
+	scannerType  = reflect.TypeFor[sql.Scanner]()
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+	bytesType    = reflect.TypeFor[[]byte]()
+)
+
+// API
+
+// GetStringOr returns the string at the field path like GetString, or def when the path is missing or null
+func GetStringOr(json []byte, def string, fields ...string) (string, error) {
+	valueSlice, _, err := lookupValue(json, TYPE_UNKNOWN, fields...)
+	if err != nil || valueSlice == nil {
+		return def, err
+	}
+
+	return string(valueSlice), nil
+}
+
+// GetBoolOr returns the boolean at the field path like GetBool, or def when the path is missing or null
+func GetBoolOr(json []byte, def bool, fields ...string) (bool, error) {
+	valueSlice, _, err := lookupValue(json, TYPE_UNKNOWN, fields...)
+	if err != nil || valueSlice == nil {
+		return def, err
+	}
+
+	return ParseBool(valueSlice)
+}
+
+// GetIntOr returns the integer at the field path like GetInt, or def when the path is missing or null
+func GetIntOr(json []byte, def int, fields ...string) (int, error) {
+	valueSlice, _, err := lookupValue(json, TYPE_UNKNOWN, fields...)
+	if err != nil || valueSlice == nil {
+		return def, err
+	}
+
+	return ParseInt(valueSlice)
+}
+
+// GetFloat64Or returns the float at the field path like GetFloat64, or def when the path is missing or null
+func GetFloat64Or(json []byte, def float64, fields ...string) (float64, error) {
+	valueSlice, _, err := lookupValue(json, TYPE_UNKNOWN, fields...)
+	if err != nil || valueSlice == nil {
+		return def, err
+	}
+
+	return ParseFloat64(valueSlice)
+}
+
+// GetOr is the generic version of the Get*Or functions, it decodes the value at the field path
+// like Get and returns def when the path is missing or null
+func GetOr[T any](json []byte, def T, fields ...string) (T, error) {
+	valueSlice, kind, err := lookupValue(json, TYPE_UNKNOWN, fields...)
+	if err != nil || valueSlice == nil {
+		return def, err
+	}
+
+	var value T
+	if err := decode(&value, kind, valueSlice); err != nil {
+		return def, err
+	}
+
+	return value, nil
+}
+
+// INTERNAL
+
+// lookupValue returns the value at the field path and its type, TYPE_UNKNOWN when it is missing.
+// The value is nil when it is missing or null.
+// Any other type than expected is reported as a type mismatch, TYPE_UNKNOWN accepts every type
+func lookupValue(json []byte, expected ValueType, fields ...string) ([]byte, ValueType, error) {
+	if len(json) == 0 {
+		return nil, TYPE_UNKNOWN, ERROR_INVALID_JSON
+	}
+
+	if len(fields) == 0 {
+		return nil, TYPE_UNKNOWN, ERROR_ARGUMENTS
+	}
+
+	pos, err := findValuePosWs(json, fields...)
+	if err == ERROR_FIELD_NOT_FOUND {
+		return nil, TYPE_UNKNOWN, nil
+	}
+	if err != nil {
+		return nil, TYPE_UNKNOWN, err
+	}
+
+	actual := valueType(json, pos)
+	if actual == TYPE_NULL {
+		if _, err := extractNull(json, pos); err != nil {
+			return nil, TYPE_UNKNOWN, err
+		}
+		return nil, TYPE_NULL, nil
+	}
+
+	if expected != TYPE_UNKNOWN && actual != expected {
+		return nil, TYPE_UNKNOWN, ERROR_TYPE_MISMATCH
+	}
+
+	valueSlice, err := extractValue(json, pos)
+	if err != nil {
+		return nil, TYPE_UNKNOWN, err
+	}
+
+	return valueSlice, actual, nil
+}
+
+// expectedType returns the JSON type decoded into the go type t, TYPE_UNKNOWN when not checked
+func expectedType(t reflect.Type) ValueType {
+	if reflect.PointerTo(t).Implements(scannerType) {
+		return TYPE_UNKNOWN // sql.Null* types accept every scalar
+	}
+
+	if t == timeType || t == durationType {
+		return TYPE_UNKNOWN // strings and numbers
+	}
+
+	if t == bytesType {
+		return TYPE_STRING // base64
+	}
+
+	if slices.Contains(numberTypes, t) {
+		return TYPE_NUMBER
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return TYPE_STRING
+	case reflect.Bool:
+		return TYPE_BOOLEAN
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		return TYPE_NUMBER
+	case reflect.Array, reflect.Slice:
+		return TYPE_ARRAY
+	case reflect.Struct:
+		return TYPE_OBJECT
+	}
+	return TYPE_UNKNOWN
+}
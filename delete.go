@@ -0,0 +1,69 @@
+package jsonparser
+
+// API
+
+// Delete returns a copy of json without the object member or array element at the field path.
+// The comma and the whitespace that separated it from its neighbours are removed with it,
+// the rest of the document is kept byte for byte. Keys are given unescaped as in Set
+func Delete(json []byte, fields ...string) ([]byte, error) {
+	start, end, err := deleteRange(json, escapeFields(fields))
+	if err != nil {
+		return nil, err
+	}
+
+	return splice(json, start, end, nil), nil
+}
+
+// INTERNAL
+
+// deleteRange returns the [start, end) range to cut to remove the member at the field path
+func deleteRange(json []byte, fields []string) (int, int, error) {
+	if len(fields) == 0 {
+		return -1, -1, ERROR_ARGUMENTS
+	}
+
+	parent, parentEnd, missing, err := locate(json, fields[:len(fields)-1])
+	if err != nil {
+		return -1, -1, err
+	}
+	if missing != len(fields)-1 {
+		return -1, -1, ERROR_FIELD_NOT_FOUND
+	}
+
+	field := fields[len(fields)-1]
+	if json[parent] == '[' && !isNumericField(field) || json[parent] != '[' && json[parent] != '{' {
+		return -1, -1, ERROR_TYPE_MISMATCH
+	}
+
+	// the member, the end of the value before it and the start of the member after it
+	member, end, prevEnd, next := -1, -1, -1, -1
+	i := 0
+	err = memberEach(json, parent, func(key []byte, m, _, e int) error {
+		if member >= 0 {
+			next = m
+			return errStop
+		}
+		if fieldMatches(field, key, i) {
+			member, end = m, e
+		} else {
+			prevEnd = e
+		}
+		i++
+		return nil
+	})
+	if err != nil && err != errStop {
+		return -1, -1, err
+	}
+
+	switch {
+	case member < 0:
+		return -1, -1, ERROR_FIELD_NOT_FOUND
+	case next >= 0:
+		return member, next, nil // up to the next member, its comma included
+	case prevEnd >= 0:
+		return prevEnd, end, nil // the last member, from the comma before it
+	}
+
+	// the only member, the container is left empty
+	return parent + 1, parentEnd - 1, nil
+}
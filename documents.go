@@ -0,0 +1,159 @@
+package jsonparser
+
+import (
+	"bytes"
+	"io"
+)
+
+// recordSeparator starts every record of a JSON text sequence (RFC 7464)
+const recordSeparator = 0x1E
+
+// DocumentScanner splits a stream of JSON documents: concatenated values with or without
+// whitespace between them ({...}{...}), and JSON text sequences where every record starts with RS.
+// A corrupted record of a text sequence is skipped up to the next RS and counted by Skipped,
+// in concatenated input a corrupted value can only be skipped if a RS follows it
+type DocumentScanner struct {
+	streamReader
+	doc     []byte
+	skipped int
+	err     error
+}
+
+// API
+
+func NewDocumentScanner(r io.Reader) *DocumentScanner {
+	return &DocumentScanner{streamReader: newStreamReader(r)}
+}
+
+// Next moves to the next document, it returns false at the end of the stream or on an error
+func (s *DocumentScanner) Next() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for {
+		rs, err := s.skipSeparators()
+		if err == io.EOF {
+			return false
+		}
+		if err != nil {
+			s.err = err
+			return false
+		}
+
+		if rs {
+			record, err := s.readUntil(recordSeparator)
+			if err != nil {
+				s.err = err
+				return false
+			}
+
+			doc, ok := sequenceValue(record)
+			if !ok {
+				s.skipped++
+				continue
+			}
+
+			s.doc = doc
+			return true
+		}
+
+		doc, err := s.readValue()
+		if err != nil {
+			found, resyncErr := s.resync()
+			if resyncErr != nil {
+				s.err = resyncErr
+				return false
+			}
+			if !found {
+				s.err = err
+				return false
+			}
+			s.skipped++
+			continue
+		}
+
+		s.doc = doc
+		return true
+	}
+}
+
+// Document returns the current document, it aliases an internal buffer and is only valid until the next call to Next
+func (s *DocumentScanner) Document() []byte {
+	return s.doc
+}
+
+// Skipped returns the number of corrupted records skipped so far
+func (s *DocumentScanner) Skipped() int {
+	return s.skipped
+}
+
+// Err returns the error that stopped Next, if any
+func (s *DocumentScanner) Err() error {
+	return s.err
+}
+
+// INTERNAL
+
+// skipSeparators skips whitespace and RS before the next document, it reports whether a RS was skipped
+func (s *DocumentScanner) skipSeparators() (bool, error) {
+	rs := false
+
+	for {
+		for s.start < s.end {
+			c := s.buf[s.start]
+			switch {
+			case c == recordSeparator:
+				rs = true
+			case !isWhitespace(c):
+				return rs, nil
+			}
+			s.start++
+		}
+
+		if s.eof {
+			return rs, io.EOF
+		}
+		if err := s.fill(); err != nil {
+			return rs, err
+		}
+	}
+}
+
+// resync moves to the next RS, the data before it is dropped as it is read. found is false at the end of the input
+func (s *DocumentScanner) resync() (found bool, err error) {
+	for {
+		if i := bytes.IndexByte(s.buf[s.start:s.end], recordSeparator); i >= 0 {
+			s.start += i
+			return true, nil
+		}
+
+		s.start = s.end
+		if s.eof {
+			return false, nil
+		}
+		if err := s.fill(); err != nil {
+			return false, err
+		}
+	}
+}
+
+// sequenceValue returns the value of a text sequence record, ok is false when the record is corrupted.
+// A number that ends the record without whitespace may be truncated and is rejected, as RFC 7464 asks
+func sequenceValue(record []byte) ([]byte, bool) {
+	pos := skipWhitespace(record, 0)
+	if pos >= len(record) {
+		return nil, false
+	}
+
+	end, err := valueEnd(record, pos)
+	if err != nil || skipWhitespace(record, end) != len(record) {
+		return nil, false
+	}
+
+	if valueType(record, pos) == TYPE_NUMBER && end == len(record) {
+		return nil, false
+	}
+
+	return record[pos:end], true
+}
@@ -0,0 +1,337 @@
+package jsonparser
+
+import (
+	"slices"
+	"strconv"
+)
+
+// Editor collects set, delete and rename operations and applies them together: their byte ranges
+// are resolved in one scan of the document and the result is written once. Every path refers to
+// the document before the edits, e.g. the indexes of an array are not shifted by a delete
+type Editor struct {
+	ops   []editOp
+	edits []edit
+}
+
+type editKind int
+
+const (
+	editSet editKind = iota
+	editDelete
+	editRename
+)
+
+// editOp is a collected operation, value is the raw JSON for a set and the escaped new key for a rename
+type editOp struct {
+	kind   editKind
+	fields []string
+	value  []byte
+}
+
+// edit replaces json[start:end] with value
+type edit struct {
+	start int
+	end   int
+	value []byte
+}
+
+// editMember is a member of a container being edited
+type editMember struct {
+	key     []byte
+	member  int
+	end     int
+	deleted bool
+	renamed []byte
+}
+
+// API
+
+func NewEditor() *Editor {
+	return &Editor{}
+}
+
+// Set replaces the value at the field path with value, a raw JSON value, or creates it as Set does.
+// The Editor keeps value and fields until Apply
+func (e *Editor) Set(value []byte, fields ...string) {
+	e.ops = append(e.ops, editOp{kind: editSet, fields: escapeFields(fields), value: value})
+}
+
+// Delete removes the object member or array element at the field path as Delete does
+func (e *Editor) Delete(fields ...string) {
+	e.ops = append(e.ops, editOp{kind: editDelete, fields: escapeFields(fields)})
+}
+
+// Rename changes the key of the object member at the field path to key. Like the fields,
+// key is given unescaped and is escaped as in a JSON string
+func (e *Editor) Rename(key string, fields ...string) {
+	e.ops = append(e.ops, editOp{kind: editRename, fields: escapeFields(fields), value: appendEscaped([]byte{}, key)})
+}
+
+// Reset removes the collected operations
+func (e *Editor) Reset() {
+	e.ops = e.ops[:0]
+}
+
+// Apply appends json with every operation applied to dst and returns the extended buffer.
+// Operations on the same path or on a path inside another, and renames to an existing key,
+// fail with ERROR_EDIT_CONFLICT. On error dst is returned unchanged
+func (e *Editor) Apply(dst, json []byte) ([]byte, error) {
+	e.edits = e.edits[:0]
+
+	for i, op := range e.ops {
+		if op.kind == editSet {
+			v, err := Parse(op.value)
+			if err != nil {
+				return dst, err
+			}
+			e.ops[i].value = v.raw
+		}
+		if op.kind != editSet && len(op.fields) == 0 {
+			return dst, ERROR_ARGUMENTS
+		}
+		for _, other := range e.ops[:i] {
+			if isPathPrefix(op.fields, other.fields) || isPathPrefix(other.fields, op.fields) {
+				return dst, ERROR_EDIT_CONFLICT
+			}
+		}
+	}
+
+	start := skipWhitespace(json, 0)
+	if start >= len(json) {
+		return dst, ERROR_INVALID_JSON
+	}
+	end, err := valueEnd(json, start)
+	if err != nil {
+		return dst, err
+	}
+
+	if len(e.ops) == 1 && len(e.ops[0].fields) == 0 {
+		e.edits = append(e.edits, edit{start: start, end: end, value: e.ops[0].value})
+	} else if len(e.ops) > 0 {
+		ops := make([]int, len(e.ops))
+		for i := range ops {
+			ops[i] = i
+		}
+		if err := e.resolve(json, start, end, 0, ops); err != nil {
+			return dst, err
+		}
+	}
+
+	// insertions are empty ranges, they go before a cut starting at the same position
+	slices.SortStableFunc(e.edits, func(a, b edit) int {
+		if a.start != b.start {
+			return a.start - b.start
+		}
+		return a.end - b.end
+	})
+
+	size := len(json)
+	for i, ed := range e.edits {
+		if i > 0 && ed.start < e.edits[i-1].end {
+			return dst, ERROR_EDIT_CONFLICT
+		}
+		size += len(ed.value) - (ed.end - ed.start)
+	}
+
+	dst = slices.Grow(dst, size)
+	prev := 0
+	for _, ed := range e.edits {
+		dst = append(dst, json[prev:ed.start]...)
+		dst = append(dst, ed.value...)
+		prev = ed.end
+	}
+	return append(dst, json[prev:]...), nil
+}
+
+// INTERNAL
+
+// resolve turns the operations ops, whose paths go through the container in [start, end)
+// at depth, into edits. Only the containers on the path of an operation are scanned
+func (e *Editor) resolve(json []byte, start, end, depth int, ops []int) error {
+	object := json[start] == '{'
+	if !object && json[start] != '[' {
+		return ERROR_TYPE_MISMATCH
+	}
+
+	var members []editMember
+	matched := make([]bool, len(ops))
+	pending := len(ops)
+
+	// the scan stops once every operation is matched, unless a rename needs every key to be checked
+	allKeys := false
+	for _, op := range ops {
+		allKeys = allKeys || e.ops[op].kind == editRename && len(e.ops[op].fields) == depth+1
+	}
+
+	err := memberEach(json, start, func(key []byte, member, valueStart, valueEnd int) error {
+		index := len(members)
+		members = append(members, editMember{key: key, member: member, end: valueEnd})
+
+		var children []int
+		for i, op := range ops {
+			if !fieldMatches(e.ops[op].fields[depth], key, index) {
+				continue
+			}
+			matched[i] = true
+			pending--
+
+			if len(e.ops[op].fields) > depth+1 {
+				children = append(children, op)
+				continue
+			}
+
+			switch e.ops[op].kind {
+			case editSet:
+				e.edits = append(e.edits, edit{start: valueStart, end: valueEnd, value: e.ops[op].value})
+			case editDelete:
+				members[index].deleted = true
+			case editRename:
+				if key == nil {
+					return ERROR_TYPE_MISMATCH
+				}
+				members[index].renamed = e.ops[op].value
+				e.edits = append(e.edits, edit{start: member + 1, end: member + 1 + len(key), value: e.ops[op].value})
+			}
+		}
+
+		if children != nil {
+			if err := e.resolve(json, valueStart, valueEnd, depth+1, children); err != nil {
+				return err
+			}
+		}
+
+		// a deleted member needs the start of the next one
+		if pending == 0 && !allKeys && !members[index].deleted {
+			return errStop
+		}
+		return nil
+	})
+	if err != nil && err != errStop {
+		return err
+	}
+
+	// the operations on missing members, only a set can create one
+	var created []string
+	if pending > 0 {
+		indent, colon, _ := containerStyle(json, start, end)
+		last := end - 1
+		comma := false
+		for i := len(members) - 1; i >= 0; i-- {
+			if !members[i].deleted {
+				last, comma = members[i].end, true
+				break
+			}
+		}
+
+		for i, op := range ops {
+			if matched[i] {
+				continue
+			}
+
+			field := e.ops[op].fields[depth]
+			if !object && !isNumericField(field) {
+				return ERROR_TYPE_MISMATCH
+			}
+			if e.ops[op].kind != editSet || (!object && field != strconv.Itoa(len(members))) {
+				return ERROR_FIELD_NOT_FOUND
+			}
+			if slices.Contains(created, field) {
+				continue // built with the first operation creating it
+			}
+			created = append(created, field)
+
+			// the operations creating the same member build it together
+			var paths [][]string
+			var values [][]byte
+			for j := i; j < len(ops); j++ {
+				if !matched[j] && e.ops[ops[j]].fields[depth] == field {
+					paths = append(paths, e.ops[ops[j]].fields[depth+1:])
+					values = append(values, e.ops[ops[j]].value)
+				}
+			}
+
+			// the first member of an emptied container is not indented, as in an empty one
+			memberIndent := indent
+			if !comma {
+				memberIndent = nil
+			}
+			member, err := newMember(object, memberIndent, colon, comma, field, paths, values)
+			if err != nil {
+				return err
+			}
+			e.edits = append(e.edits, edit{start: last, end: last, value: member})
+			comma = true
+		}
+	}
+
+	if object {
+		if err := checkKeys(members, created); err != nil {
+			return err
+		}
+	}
+
+	e.deleteMembers(start, end, members)
+	return nil
+}
+
+// deleteMembers adds the cuts removing the deleted members with their commas: a run of deleted
+// members is cut up to the next member kept, or from the end of the previous one at the end
+func (e *Editor) deleteMembers(start, end int, members []editMember) {
+	prevEnd := -1 // end of the last member kept
+
+	for i := 0; i < len(members); i++ {
+		if !members[i].deleted {
+			prevEnd = members[i].end
+			continue
+		}
+
+		first := i
+		for i+1 < len(members) && members[i+1].deleted {
+			i++
+		}
+
+		switch {
+		case i+1 < len(members):
+			e.edits = append(e.edits, edit{start: members[first].member, end: members[i+1].member})
+		case prevEnd >= 0:
+			e.edits = append(e.edits, edit{start: prevEnd, end: members[i].end})
+		default:
+			e.edits = append(e.edits, edit{start: start + 1, end: end - 1}) // every member is deleted
+		}
+	}
+}
+
+// checkKeys fails if the members left in an object after renames and creations share a key
+func checkKeys(members []editMember, created []string) error {
+	renamed := false
+	for _, m := range members {
+		renamed = renamed || m.renamed != nil
+	}
+	if !renamed {
+		return nil // the keys of the document and the created ones are already distinct
+	}
+
+	keys := make(map[string]bool, len(members)+len(created))
+	for _, key := range created {
+		keys[key] = true
+	}
+	for _, m := range members {
+		key := m.key
+		if m.renamed != nil {
+			key = m.renamed
+		}
+		if m.deleted {
+			continue
+		}
+		if keys[string(key)] {
+			return ERROR_EDIT_CONFLICT
+		}
+		keys[string(key)] = true
+	}
+	return nil
+}
+
+// isPathPrefix reports whether prefix is a prefix of fields or equal to it
+func isPathPrefix(prefix, fields []string) bool {
+	return len(prefix) <= len(fields) && slices.Equal(prefix, fields[:len(prefix)])
+}
@@ -0,0 +1,120 @@
+package jsonparser
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// errStop is returned by internal callbacks to end a walk early, it never reaches the caller
+var errStop = fmt.Errorf("stop")
+
+// PathSegment is a single step of a path: an object key or an array index.
+// Array elements have an empty Key, object members have Index -1
+type PathSegment struct {
+	Key   string
+	Index int
+}
+
+// String returns the segment in the same form accepted by the getters fields
+func (s PathSegment) String() string {
+	if s.Index >= 0 {
+		return strconv.Itoa(s.Index)
+	}
+	return s.Key
+}
+
+// API
+
+// FindAll walks every object at every depth and calls callback for each member named key,
+// with the full path to the member and its value.
+// The path slice and its keys alias internal buffers and json, they are only valid during the callback.
+// Returning an error from callback stops the walk and the error is returned by FindAll
+func FindAll(json []byte, key string, callback func(path []PathSegment, value []byte) error) error {
+	if len(json) == 0 {
+		return ERROR_INVALID_JSON
+	}
+
+	path := make([]PathSegment, 0, 16)
+	_, err := findAll(json, 0, key, path, callback)
+
+	return err
+}
+
+// FindFirst returns the path and the value of the first member named key, at any depth
+func FindFirst(json []byte, key string) ([]PathSegment, []byte, error) {
+	var (
+		resPath  []PathSegment
+		resValue []byte
+	)
+
+	err := FindAll(json, key, func(path []PathSegment, value []byte) error {
+		resPath = slices.Clone(path)
+		for i := range resPath {
+			resPath[i].Key = strings.Clone(resPath[i].Key)
+		}
+		resValue = value
+		return errStop
+	})
+
+	if err == errStop {
+		return resPath, resValue, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nil, nil, ERROR_FIELD_NOT_FOUND
+}
+
+// INTERNAL
+
+// findAll descends the value starting at pos, path is used as a stack and never reallocated
+// unless the document is deeper than its capacity
+func findAll(json []byte, pos int, key string, path []PathSegment, callback func(path []PathSegment, value []byte) error) (int, error) {
+	pos = skipWhitespace(json, pos)
+	if pos >= len(json) {
+		return -1, ERROR_INVALID_JSON
+	}
+
+	switch json[pos] {
+	case '{':
+		return objectEach(json, pos, func(k []byte, start, end int) error {
+			path := append(path, PathSegment{Key: unsafe.String(unsafe.SliceData(k), len(k)), Index: -1})
+
+			if string(k) == key {
+				value, err := extractValue(json, start)
+				if err != nil {
+					return err
+				}
+
+				if err := callback(path, value); err != nil {
+					return err
+				}
+			}
+
+			return findAllNested(json, start, key, path, callback)
+		})
+
+	case '[':
+		return arrayEach(json, pos, func(index, start, end int) error {
+			path := append(path, PathSegment{Index: index})
+			return findAllNested(json, start, key, path, callback)
+		})
+
+	default:
+		return valueEnd(json, pos)
+	}
+}
+
+// findAllNested descends only into containers, scalars have already been scanned by the caller
+func findAllNested(json []byte, pos int, key string, path []PathSegment, callback func(path []PathSegment, value []byte) error) error {
+	if json[pos] != '{' && json[pos] != '[' {
+		return nil
+	}
+
+	_, err := findAll(json, pos, key, path, callback)
+	return err
+}
@@ -0,0 +1,158 @@
+package jsonparser
+
+import (
+	"iter"
+)
+
+// ValueType is the JSON type of a value, detected from its first byte
+type ValueType int
+
+const (
+	TYPE_UNKNOWN ValueType = iota
+	TYPE_STRING
+	TYPE_NUMBER
+	TYPE_OBJECT
+	TYPE_ARRAY
+	TYPE_BOOLEAN
+	TYPE_NULL
+)
+
+func (t ValueType) String() string {
+	switch t {
+	case TYPE_STRING:
+		return "string"
+	case TYPE_NUMBER:
+		return "number"
+	case TYPE_OBJECT:
+		return "object"
+	case TYPE_ARRAY:
+		return "array"
+	case TYPE_BOOLEAN:
+		return "boolean"
+	case TYPE_NULL:
+		return "null"
+	}
+	return "unknown"
+}
+
+// API
+
+// Exists reports whether the field path is present in json
+func Exists(json []byte, fields ...string) bool {
+	if len(json) == 0 {
+		return false
+	}
+
+	pos, err := findValuePos(json, fields...)
+	if err != nil {
+		return false
+	}
+
+	return skipWhitespace(json, pos) < len(json)
+}
+
+// Type returns the type of the value at the field path, no fields returns the type of the document
+func Type(json []byte, fields ...string) (ValueType, error) {
+	pos, err := findValuePosWs(json, fields...)
+	if err != nil {
+		return TYPE_UNKNOWN, err
+	}
+
+	return valueType(json, pos), nil
+}
+
+// Len returns the number of elements of an array, the number of members of an object
+// or the length in bytes of a string as returned by GetString.
+// No fields returns the length of the document
+func Len(json []byte, fields ...string) (int, error) {
+	pos, err := findValuePosWs(json, fields...)
+	if err != nil {
+		return -1, err
+	}
+
+	count := 0
+
+	switch json[pos] {
+	case '[':
+		_, err = arrayEach(json, pos, func(index, start, end int) error {
+			count++
+			return nil
+		})
+	case '{':
+		_, err = objectEach(json, pos, func(key []byte, start, end int) error {
+			count++
+			return nil
+		})
+	case '"':
+		var str []byte
+		str, err = extractString(json, pos)
+		count = len(str)
+	default:
+		return -1, ERROR_ARGUMENTS
+	}
+
+	if err != nil {
+		return -1, err
+	}
+
+	return count, nil
+}
+
+// Keys returns an iterator over the keys of the object at the field path, in document order.
+// Nothing is yielded when the path is missing or is not an object, use Type to tell them apart
+func Keys(json []byte, fields ...string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		pos, err := findValuePosWs(json, fields...)
+		if err != nil || json[pos] != '{' {
+			return
+		}
+
+		objectEach(json, pos, func(key []byte, start, end int) error {
+			if !yield(string(key)) {
+				return errStop
+			}
+			return nil
+		})
+	}
+}
+
+// INTERNAL
+
+// findValuePosWs is findValuePos followed by the whitespace before the value,
+// it fails if no value is found
+func findValuePosWs(json []byte, fields ...string) (int, error) {
+	if len(json) == 0 {
+		return -1, ERROR_INVALID_JSON
+	}
+
+	pos, err := findValuePos(json, fields...)
+	if err != nil {
+		return -1, err
+	}
+
+	pos = skipWhitespace(json, pos)
+	if pos >= len(json) {
+		return -1, ERROR_INVALID_JSON
+	}
+
+	return pos, nil
+}
+
+// valueType detects the type of the value starting at pos without validating it
+func valueType(json []byte, pos int) ValueType {
+	switch json[pos] {
+	case '"':
+		return TYPE_STRING
+	case '{':
+		return TYPE_OBJECT
+	case '[':
+		return TYPE_ARRAY
+	case 't', 'f':
+		return TYPE_BOOLEAN
+	case 'n':
+		return TYPE_NULL
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return TYPE_NUMBER
+	}
+	return TYPE_UNKNOWN
+}
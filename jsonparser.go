@@ -2,7 +2,9 @@ package jsonparser
 
 import (
 	"bytes"
+	"database/sql"
 	"fmt"
+	"math"
 	"reflect"
 	"strconv"
 	"unsafe"
@@ -20,6 +22,14 @@ var (
 	ERROR_INVALID_NULL       = fmt.Errorf("invalid null")
 	ERROR_INVALID_ARRAY      = fmt.Errorf("invalid array")
 	ERROR_UNTERMINATED_ARRAY = fmt.Errorf("unterminated array")
+	ERROR_TYPE_MISMATCH      = fmt.Errorf("type mismatch")
+	ERROR_INTEGER_OVERFLOW   = fmt.Errorf("integer overflow")
+	ERROR_INVALID_TIME       = fmt.Errorf("invalid time")
+	ERROR_INVALID_DURATION   = fmt.Errorf("invalid duration")
+	ERROR_EDIT_CONFLICT      = fmt.Errorf("conflicting edits")
+	ERROR_INVALID_POINTER    = fmt.Errorf("invalid JSON pointer")
+	ERROR_INVALID_PATCH      = fmt.Errorf("invalid patch")
+	ERROR_TEST_FAILED        = fmt.Errorf("test operation failed")
 )
 
 type irange struct {
@@ -38,7 +48,7 @@ func Get[T any](value *T, json []byte, fields ...string) (*T, error) {
 		return nil, ERROR_ARGUMENTS
 	}
 
-	pos, err := findValuePos(json, fields...)
+	pos, err := findValuePosWs(json, fields...)
 	if err != nil {
 		return nil, err
 	}
@@ -48,12 +58,45 @@ func Get[T any](value *T, json []byte, fields ...string) (*T, error) {
 		return nil, err
 	}
 
-	valueRes, err := get(value, valueSlice, 0)
-	if err != nil {
+	if err := decode(value, valueType(json, pos), valueSlice); err != nil {
 		return nil, err
 	}
 
-	return valueRes, nil
+	return value, nil
+}
+
+// decode stores the value in the target, kind is the JSON type of the value
+// since slice has lost the quotes of strings
+func decode[T any](value *T, kind ValueType, slice []byte) error {
+	if handled, err := decodeSpecial(value, kind, slice); handled {
+		return err
+	}
+
+	_, err := get(value, slice, 0)
+	return err
+}
+
+// decodeSpecial handles the targets recognized by their type rather than by their kind
+func decodeSpecial(value any, kind ValueType, slice []byte) (bool, error) {
+	if handled, err := decodeNumber(value, kind, slice); handled {
+		return true, err
+	}
+
+	if handled, err := decodeTime(value, kind, slice); handled {
+		return true, err
+	}
+
+	if handled, err := decodeBytes(value, kind, slice); handled {
+		return true, err
+	}
+
+	switch target := value.(type) {
+	case sql.Scanner:
+		// sql.NullString, sql.NullInt64 and similar need to know if the value is null
+		return true, scanValue(target, kind, slice)
+	}
+
+	return false, nil
 }
 
 func get[T any](value *T, slice []byte, depth int) (*T, error) {
@@ -152,39 +195,24 @@ func ParseBool(boolean []byte, fields ...string) (bool, error) {
 }
 
 func ParseInt(integer []byte) (int, error) {
-	resInt, err := strconv.Atoi(string(integer))
+	resInt, err := parseInt64(integer, intLimit)
 	if err != nil {
 		return -1, err
 	}
 
-	return resInt, nil
+	return int(resInt), nil
 }
 
 func ParseInt64(integer []byte) (int64, error) {
-	resInt64, err := strconv.ParseInt(string(integer), 10, 64)
-	if err != nil {
-		return -1, err
-	}
-
-	return resInt64, nil
+	return parseInt64(integer, math.MaxInt64)
 }
 
 func ParseFloat32(num []byte) (float32, error) {
-	resFloat, err := strconv.ParseFloat(string(num), 32)
-	if err != nil {
-		return -1, err
-	}
-
-	return float32(resFloat), nil
+	return parseFloat32(num)
 }
 
 func ParseFloat64(float []byte) (float64, error) {
-	resFloat, err := strconv.ParseFloat(string(float), 64)
-	if err != nil {
-		return -1, err
-	}
-
-	return resFloat, nil
+	return parseFloat64(float)
 }
 
 func Foreach(json []byte, callback func(valueSlice []byte, index int), fields ...string) error {
@@ -201,79 +229,25 @@ func Foreach(json []byte, callback func(valueSlice []byte, index int), fields ..
 		return nil
 	}
 
-	pos := valPos
-	if json[pos] != '[' {
+	if json[valPos] != '[' {
 		return ERROR_INVALID_ARRAY
 	}
 
-	pos++
-
-	for pos < len(json) && isWhitespace(json[pos]) {
-		pos++
-	}
-
-	if json[pos] == ']' {
-		return nil // empty array
-	}
-
-	// before '[' extractValue returns the whole array
-	// after  '[' extractValue returns the first element of the array
-
-	//callback on element [0]
-
-	//TODO find an elegant way to handle empty arrays
-	//if empty array extract value it goes to ']' switch to default extract (which is number)
-	//extract number not found number retunr the position of the first char not part of the number
-	//which is ] escluded -> it returns empty slice json[pos:pos] = [ without the closing ']'
-	valSlice, err := extractValue(json, pos)
-	if err != nil {
-		return err
-	}
-
-	index := 0
-	callback(valSlice, index)
-
-	pos += len(valSlice)
-
-	for pos < len(json) {
-
-		switch json[pos] {
-		case ',':
-			pos++ //skip comma
-			valSlice, err := extractValue(json, pos)
-			if err != nil {
-				return err
-			}
-
-			index++
-			callback(valSlice, index)
-
-			pos += len(valSlice)
-		case ']':
-			return nil // Successfully reached end of array
-		default:
-			pos++
+	_, err = arrayEach(json, valPos, func(index, start, end int) error {
+		valSlice, err := extractValue(json, start)
+		if err != nil {
+			return err
 		}
-	}
 
-	return ERROR_INVALID_ARRAY
+		callback(valSlice, index)
+		return nil
+	})
+
+	return err
 }
 
 func GetString(json []byte, fields ...string) (string, error) {
-	if len(json) == 0 {
-		return "", ERROR_INVALID_JSON
-	}
-
-	if len(fields) == 0 {
-		return "", ERROR_ARGUMENTS
-	}
-
-	pos, err := findValuePos(json, fields...)
-	if err != nil {
-		return "", err
-	}
-
-	valueSlice, err := extractValue(json, pos)
+	valueSlice, err := lookupString(json, fields...)
 	if err != nil {
 		return "", err
 	}
@@ -399,7 +373,8 @@ func findValuePos(json []byte, fields ...string) (int, error) {
 	pos := 0
 
 	for _, field := range fields {
-		if isNumericField(field) {
+		// a numeric field is an index in an array and a key in an object
+		if start := skipWhitespace(json, 0); isNumericField(field) && (start >= len(json) || json[start] != '{') {
 			intField, err := strconv.Atoi(field)
 			if err != nil {
 				return -1, err
@@ -432,6 +407,138 @@ func isWhitespace(b byte) bool {
 	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
 }
 
+// skipWhitespace returns the position of the first non whitespace char starting from pos
+func skipWhitespace(json []byte, pos int) int {
+	for pos < len(json) && isWhitespace(json[pos]) {
+		pos++
+	}
+	return pos
+}
+
+// valueEnd returns the position right after the value starting from pos,
+// closing quote of strings included
+func valueEnd(json []byte, pos int) (int, error) {
+	pos = skipWhitespace(json, pos)
+	if pos >= len(json) {
+		return -1, ERROR_INVALID_JSON
+	}
+
+	slice, err := extractValue(json, pos)
+	if err != nil {
+		return -1, err
+	}
+
+	if json[pos] == '"' {
+		return pos + len(slice) + 2, nil
+	}
+
+	// extractNumber returns an empty slice when there is no value at all, e.g. "[]" or "[1,]"
+	if len(slice) == 0 {
+		return -1, ERROR_INVALID_JSON
+	}
+
+	return pos + len(slice), nil
+}
+
+// objectEach calls fn for every member of the object starting at pos with the key
+// (without quotes) and the [start, end) range of the raw value.
+// It returns the position right after the closing brace
+func objectEach(json []byte, pos int, fn func(key []byte, start, end int) error) (int, error) {
+	pos = skipWhitespace(json, pos)
+	if pos >= len(json) || json[pos] != '{' {
+		return -1, ERROR_INVALID_JSON
+	}
+
+	pos = skipWhitespace(json, pos+1)
+	if pos < len(json) && json[pos] == '}' {
+		return pos + 1, nil // empty object
+	}
+
+	for pos < len(json) {
+		if json[pos] != '"' {
+			return -1, ERROR_INVALID_JSON
+		}
+
+		key, err := extractString(json, pos)
+		if err != nil {
+			return -1, err
+		}
+
+		pos = skipWhitespace(json, pos+len(key)+2)
+		if pos >= len(json) || json[pos] != ':' {
+			return -1, ERROR_COLON_NOT_FOUND
+		}
+
+		start := skipWhitespace(json, pos+1)
+		end, err := valueEnd(json, start)
+		if err != nil {
+			return -1, err
+		}
+
+		if err := fn(key, start, end); err != nil {
+			return -1, err
+		}
+
+		pos = skipWhitespace(json, end)
+		if pos >= len(json) {
+			break
+		}
+
+		switch json[pos] {
+		case ',':
+			pos = skipWhitespace(json, pos+1)
+		case '}':
+			return pos + 1, nil
+		default:
+			return -1, ERROR_INVALID_JSON
+		}
+	}
+
+	return -1, ERROR_INVALID_JSON
+}
+
+// arrayEach calls fn for every element of the array starting at pos with its index
+// and the [start, end) range of the raw value.
+// It returns the position right after the closing bracket
+func arrayEach(json []byte, pos int, fn func(index, start, end int) error) (int, error) {
+	pos = skipWhitespace(json, pos)
+	if pos >= len(json) || json[pos] != '[' {
+		return -1, ERROR_INVALID_ARRAY
+	}
+
+	pos = skipWhitespace(json, pos+1)
+	if pos < len(json) && json[pos] == ']' {
+		return pos + 1, nil // empty array
+	}
+
+	for index := 0; pos < len(json); index++ {
+		end, err := valueEnd(json, pos)
+		if err != nil {
+			return -1, err
+		}
+
+		if err := fn(index, pos, end); err != nil {
+			return -1, err
+		}
+
+		pos = skipWhitespace(json, end)
+		if pos >= len(json) {
+			break
+		}
+
+		switch json[pos] {
+		case ',':
+			pos = skipWhitespace(json, pos+1)
+		case ']':
+			return pos + 1, nil
+		default:
+			return -1, ERROR_INVALID_ARRAY
+		}
+	}
+
+	return -1, ERROR_UNTERMINATED_ARRAY
+}
+
 // isNumericField checks if a field string represents a number without allocating
 func isNumericField(s string) bool {
 	if len(s) == 0 {
@@ -462,60 +569,60 @@ func findArrayValuePos(json []byte, pos int, elementIndex int) (int, error) {
 		return -1, ERROR_INVALID_JSON
 	}
 
-	if json[pos] != '[' {
-		return -1, ERROR_INVALID_JSON
+	for pos < len(json) && isWhitespace(json[pos]) {
+		pos++
 	}
 
+	//check if is an array
+	if pos >= len(json) || json[pos] != '[' {
+		return -1, ERROR_FIELD_NOT_FOUND
+	}
 	pos++
-	isValue := false
 	index := 0
 
 	if elementIndex == 0 {
 		for pos < len(json) && isWhitespace(json[pos]) {
 			pos++
 		}
+		if pos >= len(json) || json[pos] == ']' {
+			return -1, ERROR_FIELD_NOT_FOUND // empty array
+		}
 		return pos, nil
 	}
 
 	for pos < len(json) {
 		switch json[pos] {
 		case ',':
-			if !isValue {
-				index++
-				if elementIndex == index {
-					pos++ //skip comma and whitespace
-					for pos < len(json) && isWhitespace(json[pos]) {
-						pos++
-					}
-					return pos, nil
+			index++
+			if elementIndex == index {
+				pos++ //skip comma and whitespace
+				for pos < len(json) && isWhitespace(json[pos]) {
+					pos++
 				}
+				return pos, nil
 			}
 			pos++
 		case '"':
-			if json[pos-1] != '\\' {
-				isValue = !isValue
+			// skip the whole string so its content is not scanned
+			str, err := extractString(json, pos)
+			if err != nil {
+				return -1, err
 			}
-			pos++
+			pos += len(str) + 2
 		case '{':
-			if json[pos-1] != '\\' {
-				posRes, err := skipObject(json, pos)
-				if err != nil {
-					return -1, err
-				}
-				pos = posRes
-				continue
+			posRes, err := skipObject(json, pos)
+			if err != nil {
+				return -1, err
 			}
-			pos++
+			pos = posRes
 		case '[':
-			if json[pos-1] != '\\' {
-				posRes, err := skipMatrix(json, pos)
-				if err != nil {
-					return -1, err
-				}
-				pos = posRes
-				continue
+			posRes, err := skipMatrix(json, pos)
+			if err != nil {
+				return -1, err
 			}
-			pos++
+			pos = posRes
+		case ']':
+			return -1, ERROR_FIELD_NOT_FOUND // end of the array reached
 		default:
 			pos++
 		}
@@ -535,9 +642,10 @@ func findFieldValuePos(json []byte, pos int, field string) (int, error) {
 	}
 
 	//check if is an object
-	if json[pos] == '{' {
-		pos++
+	if pos >= len(json) || json[pos] != '{' {
+		return -1, ERROR_FIELD_NOT_FOUND
 	}
+	pos++
 
 	// We are looking for the field at the same relative depth we called this function
 	depth := 0
@@ -552,7 +660,8 @@ func findFieldValuePos(json []byte, pos int, field string) (int, error) {
 
 				candidate := irange{start: pos + 1, end: pos + 1 + len(field)}
 
-				if json[candidate.end] == '"' &&
+				if candidate.end < len(json) &&
+					json[candidate.end] == '"' &&
 					bytes.Equal(json[candidate.start:candidate.end], []byte(field)) {
 
 					pos, err := nextColon(json, candidate.end)
@@ -568,7 +677,15 @@ func findFieldValuePos(json []byte, pos int, field string) (int, error) {
 					return pos, nil
 				}
 			}
-			pos++
+
+			// skip the whole key or value so its content is not scanned
+			str, err := extractString(json, pos)
+			if err != nil {
+				return -1, err
+			}
+			pos += len(str) + 2
+		case '}':
+			return -1, ERROR_FIELD_NOT_FOUND // end of the object reached
 		case '{':
 			if pos > 0 && json[pos-1] != '\\' {
 				isValue = false
@@ -614,27 +731,7 @@ func skipObject(json []byte, pos int) (int, error) {
 		return -1, ERROR_INVALID_JSON
 	}
 
-	depth := 1
-	pos++
-
-	for pos < len(json) {
-		switch json[pos] {
-		case '{':
-			if json[pos-1] != '\\' {
-				depth++
-			}
-		case '}':
-			if json[pos-1] != '\\' {
-				depth--
-				if depth == 0 {
-					return pos + 1, nil
-				}
-			}
-		}
-		pos++
-	}
-
-	return -1, ERROR_INVALID_JSON
+	return skipContainer(json, pos, '{', '}')
 }
 
 func skipMatrix(json []byte, pos int) (int, error) {
@@ -642,21 +739,30 @@ func skipMatrix(json []byte, pos int) (int, error) {
 		return -1, ERROR_INVALID_JSON
 	}
 
-	count := 1
+	return skipContainer(json, pos, '[', ']')
+}
+
+// skipContainer returns the position after the container opened at pos,
+// strings are skipped whole so the brackets inside them are not counted
+func skipContainer(json []byte, pos int, open, close byte) (int, error) {
+	depth := 1
 	pos++
 
 	for pos < len(json) {
 		switch json[pos] {
-		case '[':
-			if json[pos-1] != '\\' {
-				count++
+		case '"':
+			str, err := extractString(json, pos)
+			if err != nil {
+				return -1, err
 			}
-		case ']':
-			if json[pos-1] != '\\' {
-				count--
-				if count == 0 {
-					return pos + 1, nil
-				}
+			pos += len(str) + 2
+			continue
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return pos + 1, nil
 			}
 		}
 		pos++
@@ -726,12 +832,14 @@ func extractString(json []byte, pos int) ([]byte, error) {
 		start = pos
 	}
 
-	// Find closing quote
+	// Find closing quote, skipping escaped characters
 	for pos < len(json) {
-		if json[pos] == '"' {
-			if pos > 0 && json[pos-1] != '\\' {
-				return json[start+1 : pos], nil
-			}
+		switch json[pos] {
+		case '\\':
+			pos += 2
+			continue
+		case '"':
+			return json[start+1 : pos], nil
 		}
 		pos++
 	}
@@ -752,6 +860,11 @@ func extractNumber(json []byte, pos int) ([]byte, error) {
 		}
 	}
 
+	// a number can end the input when it is the whole document
+	if pos > start {
+		return json[start:pos], nil
+	}
+
 	return nil, ERROR_INVALID_JSON
 }
 
@@ -777,55 +890,24 @@ func extractObject(json []byte, pos int) ([]byte, error) {
 	depth := 0
 	isValue := false
 	escaped := false
-	isArray := false
-
-	switch json[pos] {
-	case '{':
-		if json[pos-1] != '\\' && !isValue {
-			// Start of an object
-			depth++
-		}
-	case '}':
-		if json[pos-1] != '\\' && !isValue {
-			depth--
-		}
-		pos++
-	case '[':
-		if json[pos-1] != '\\' && !isValue {
-			isArray = true
-		}
-		pos++
-	case ']':
-		if json[pos-1] != '\\' && !isValue {
-			isArray = false
-		}
-		pos++
-	case ':':
-		if json[pos-1] != '\\' {
-			isValue = true
-		}
-		pos++
-	case ',':
-		isValue = isArray
-		pos++
-	}
 
 	for pos < len(json) {
+		char := json[pos]
 
 		if isValue {
 			if escaped {
 				escaped = false
-			} else if json[pos] == '\\' {
+			} else if char == '\\' {
 				escaped = true
-			} else if json[pos] == '"' {
+			} else if char == '"' {
 				isValue = false
 			}
 		} else {
-			if json[pos] == '"' {
+			if char == '"' {
 				isValue = true
-			} else if json[pos] == '{' {
+			} else if char == '{' {
 				depth++
-			} else if json[pos] == '}' {
+			} else if char == '}' {
 				depth--
 				if depth == 0 {
 					return json[start : pos+1], nil
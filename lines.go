@@ -0,0 +1,171 @@
+package jsonparser
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"math"
+	"strconv"
+)
+
+// LineError is an error on a line of a JSON Lines stream, the line numbers start at 1
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return "line " + strconv.Itoa(e.Line) + ": " + e.Err.Error()
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// LinesReader reads the records of a newline delimited JSON stream (NDJSON, JSON Lines).
+// Blank lines are skipped and "\r\n" line endings are accepted
+type LinesReader struct {
+	scanner *bufio.Scanner
+	line    int
+	record  Value
+	err     error
+}
+
+// LinesWriter writes records to a newline delimited JSON stream, one compact record per line
+type LinesWriter struct {
+	w   io.Writer
+	buf []byte
+}
+
+// API
+
+func NewLinesReader(r io.Reader) *LinesReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), math.MaxInt)
+
+	return &LinesReader{scanner: scanner}
+}
+
+// Next moves to the next record, it returns false at the end of the stream or on an error
+func (l *LinesReader) Next() bool {
+	if l.err != nil {
+		return false
+	}
+
+	for l.scanner.Scan() {
+		l.line++
+
+		line := l.scanner.Bytes()
+		if skipWhitespace(line, 0) == len(line) {
+			continue
+		}
+
+		record, err := Parse(line)
+		if err != nil {
+			l.err = &LineError{Line: l.line, Err: err}
+			return false
+		}
+
+		l.record = record
+		return true
+	}
+
+	if err := l.scanner.Err(); err != nil {
+		l.err = &LineError{Line: l.line + 1, Err: err}
+	}
+	return false
+}
+
+// Record returns the current record, it aliases an internal buffer and is only valid until the next call to Next
+func (l *LinesReader) Record() []byte {
+	return l.record.Raw()
+}
+
+// Value returns the current record as a Value, with the same lifetime as Record
+func (l *LinesReader) Value() Value {
+	return l.record
+}
+
+// Line returns the line number of the current record
+func (l *LinesReader) Line() int {
+	return l.line
+}
+
+// Err returns the error that stopped Next, a *LineError
+func (l *LinesReader) Err() error {
+	return l.err
+}
+
+// Each calls fn for every record, an error from fn stops the iteration
+// and is returned as a *LineError with the line of the record
+func (l *LinesReader) Each(fn func(record []byte) error) error {
+	for l.Next() {
+		if err := fn(l.Record()); err != nil {
+			return &LineError{Line: l.line, Err: err}
+		}
+	}
+
+	return l.Err()
+}
+
+func NewLinesWriter(w io.Writer) *LinesWriter {
+	return &LinesWriter{w: w}
+}
+
+// Write writes record on its own line with the insignificant whitespace removed,
+// record must be a single valid JSON value
+func (lw *LinesWriter) Write(record []byte) error {
+	buf, err := appendCompact(lw.buf[:0], record)
+	if err != nil {
+		return err
+	}
+
+	// a raw newline can only come from an invalid string
+	if bytes.IndexByte(buf, '\n') >= 0 {
+		return ERROR_INVALID_STRING
+	}
+
+	lw.buf = append(buf, '\n')
+	_, err = lw.w.Write(lw.buf)
+	return err
+}
+
+// INTERNAL
+
+// appendCompact appends json to dst without the whitespace between tokens, it fails on invalid JSON
+func appendCompact(dst []byte, json []byte) ([]byte, error) {
+	t := Tokenizer{json: json}
+	comma := false
+
+	for {
+		token, err := t.Next()
+		if err == io.EOF {
+			return dst, nil
+		}
+		if err != nil {
+			return dst, err
+		}
+
+		switch token.Kind {
+		case TOKEN_OBJECT_END, TOKEN_ARRAY_END:
+			dst = append(dst, json[token.Start:token.End]...)
+			comma = true
+			continue
+		}
+
+		if comma {
+			dst = append(dst, ',')
+		}
+		dst = append(dst, json[token.Start:token.End]...)
+
+		switch token.Kind {
+		case TOKEN_KEY:
+			dst = append(dst, ':')
+			comma = false
+		case TOKEN_OBJECT_START, TOKEN_ARRAY_START:
+			comma = false
+		default:
+			comma = true
+		}
+	}
+}
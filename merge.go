@@ -0,0 +1,176 @@
+package jsonparser
+
+import "bytes"
+
+// API
+
+// MergePatch applies a JSON Merge Patch (RFC 7386) to target: the members of an object patch are
+// merged recursively, a null member deletes the key and any other patch replaces the target.
+// The edits are applied in one pass with an Editor, so the keys of target keep their order
+// and formatting and new keys are appended
+func MergePatch(target, patch []byte) ([]byte, error) {
+	p, err := Parse(patch)
+	if err != nil {
+		return nil, err
+	}
+	if p.kind != TYPE_OBJECT {
+		return bytes.Clone(p.raw), nil
+	}
+
+	t, err := Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	if t.kind != TYPE_OBJECT {
+		target = []byte("{}")
+		t.raw = target
+	}
+
+	e := NewEditor()
+	if err := mergeOps(e, t.raw, p.raw, nil); err != nil {
+		return nil, err
+	}
+
+	return e.Apply(nil, target)
+}
+
+// CreateMergePatch returns the merge patch that turns original into modified: removed keys are null,
+// objects present in both are diffed recursively and the other changed values are written whole.
+// Keys follow the order of original, then the new keys of modified. As RFC 7386 notes, a null
+// inside modified can not be expressed, it becomes a deletion
+func CreateMergePatch(original, modified []byte) ([]byte, error) {
+	o, err := Parse(original)
+	if err != nil {
+		return nil, err
+	}
+	m, err := Parse(modified)
+	if err != nil {
+		return nil, err
+	}
+
+	if o.kind != TYPE_OBJECT || m.kind != TYPE_OBJECT {
+		return bytes.Clone(m.raw), nil
+	}
+
+	return diffObjects(o.raw, m.raw)
+}
+
+// INTERNAL
+
+// mergeOps adds to e the edits merging the object patch into the object target at path
+func mergeOps(e *Editor, target, patch []byte, path []string) error {
+	members, err := objectMembers(target)
+	if err != nil {
+		return err
+	}
+
+	_, err = objectEach(patch, 0, func(key []byte, start, end int) error {
+		value := patch[start:end]
+		existing, found := members[string(key)]
+
+		// the Editor takes the keys unescaped
+		unescaped, err := unescapeString(key)
+		if err != nil {
+			return err
+		}
+		fields := append(path[:len(path):len(path)], string(unescaped))
+
+		switch {
+		case valueType(value, 0) == TYPE_NULL:
+			if found {
+				e.Delete(fields...)
+			}
+
+		case valueType(value, 0) == TYPE_OBJECT && found && valueType(existing, 0) == TYPE_OBJECT:
+			return mergeOps(e, existing, value, fields)
+
+		case valueType(value, 0) == TYPE_OBJECT:
+			// the nulls of a new object are dropped as if it was merged into {}
+			merged, err := MergePatch([]byte("{}"), value)
+			if err != nil {
+				return err
+			}
+			e.Set(merged, fields...)
+
+		default:
+			e.Set(value, fields...)
+		}
+		return nil
+	})
+
+	return err
+}
+
+// diffObjects returns the merge patch between the objects original and modified
+func diffObjects(original, modified []byte) ([]byte, error) {
+	members, err := objectMembers(modified)
+	if err != nil {
+		return nil, err
+	}
+
+	dst := []byte{'{'}
+	seen := make(map[string]bool, len(members))
+
+	member := func(key []byte, value []byte) {
+		if len(dst) > 1 {
+			dst = append(dst, ',')
+		}
+		dst = append(append(append(append(dst, '"'), key...), '"', ':'), value...)
+	}
+
+	_, err = objectEach(original, 0, func(key []byte, start, end int) error {
+		value, found := members[string(key)]
+		seen[string(key)] = true
+		if !found {
+			member(key, []byte("null"))
+			return nil
+		}
+
+		old := original[start:end]
+		if valueType(old, 0) == TYPE_OBJECT && valueType(value, 0) == TYPE_OBJECT {
+			diff, err := diffObjects(old, value)
+			if err != nil {
+				return err
+			}
+			if len(diff) > 2 {
+				member(key, diff)
+			}
+			return nil
+		}
+
+		equal, err := jsonEqual(old, value)
+		if err != nil {
+			return err
+		}
+		if !equal {
+			member(key, value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = objectEach(modified, 0, func(key []byte, start, end int) error {
+		if !seen[string(key)] {
+			member(key, modified[start:end])
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return append(dst, '}'), nil
+}
+
+// objectMembers returns the raw values of the object by key
+func objectMembers(object []byte) (map[string][]byte, error) {
+	members := make(map[string][]byte)
+	_, err := objectEach(object, 0, func(key []byte, start, end int) error {
+		members[string(key)] = object[start:end]
+		return nil
+	})
+
+	return members, err
+}
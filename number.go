@@ -0,0 +1,201 @@
+package jsonparser
+
+import (
+	"math"
+	"strconv"
+	"unsafe"
+)
+
+// largest magnitude of a positive int, depends on the platform
+const intLimit = uint64(math.MaxInt)
+
+// exact powers of ten, the largest ones representable without rounding
+var float64pow10 = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10,
+	1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22,
+}
+
+var float32pow10 = [...]float32{1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10}
+
+// INTERNAL
+
+// parseInt64 parses a base 10 integer with an optional sign directly from the bytes,
+// limit is the largest magnitude allowed for positive numbers, negative ones allow limit+1
+func parseInt64(integer []byte, limit uint64) (int64, error) {
+	if len(integer) == 0 {
+		return -1, ERROR_INVALID_INTEGER
+	}
+
+	pos := 0
+	neg := false
+	switch integer[0] {
+	case '-':
+		neg = true
+		limit++
+		pos++
+	case '+':
+		pos++
+	}
+
+	if pos == len(integer) {
+		return -1, ERROR_INVALID_INTEGER
+	}
+
+	// below limit/10 one more digit can not overflow, the division is skipped
+	safe := limit / 10
+
+	var n uint64
+	for ; pos < len(integer); pos++ {
+		digit := uint64(integer[pos] - '0')
+		if digit > 9 {
+			return -1, ERROR_INVALID_INTEGER
+		}
+
+		if n >= safe && n > (limit-digit)/10 {
+			return -1, ERROR_INTEGER_OVERFLOW
+		}
+		n = n*10 + digit
+	}
+
+	if neg {
+		return int64(-n), nil
+	}
+	return int64(n), nil
+}
+
+// parseDecimal splits a number into its decimal mantissa and exponent, the trailing zeros of the fraction
+// are dropped. ok is false when the mantissa does not fit 19 digits or the syntax is not a plain decimal number,
+// the caller must then fall back to strconv
+func parseDecimal(num []byte) (mantissa uint64, exp int, neg bool, ok bool) {
+	pos := 0
+	if pos < len(num) && (num[pos] == '-' || num[pos] == '+') {
+		neg = num[pos] == '-'
+		pos++
+	}
+
+	digits := 0
+	significant := 0
+	for ; pos < len(num) && num[pos] >= '0' && num[pos] <= '9'; pos++ {
+		if mantissa == 0 && num[pos] == '0' {
+			digits++
+			continue // leading zeros
+		}
+		if significant == 19 {
+			return 0, 0, false, false
+		}
+		mantissa = mantissa*10 + uint64(num[pos]-'0')
+		significant++
+		digits++
+	}
+
+	if pos < len(num) && num[pos] == '.' {
+		pos++
+		zeros := 0 // trailing zeros are added to the mantissa only when a digit follows them
+		for ; pos < len(num) && num[pos] >= '0' && num[pos] <= '9'; pos++ {
+			digits++
+			if mantissa == 0 && num[pos] == '0' {
+				exp--
+				continue
+			}
+			if num[pos] == '0' {
+				zeros++
+				continue
+			}
+			if significant+zeros >= 19 {
+				return 0, 0, false, false
+			}
+			for ; zeros > 0; zeros-- {
+				mantissa *= 10
+				significant++
+				exp--
+			}
+			mantissa = mantissa*10 + uint64(num[pos]-'0')
+			significant++
+			exp--
+		}
+	}
+
+	if digits == 0 {
+		return 0, 0, false, false
+	}
+
+	if pos < len(num) && (num[pos] == 'e' || num[pos] == 'E') {
+		pos++
+		expNeg := false
+		if pos < len(num) && (num[pos] == '-' || num[pos] == '+') {
+			expNeg = num[pos] == '-'
+			pos++
+		}
+
+		if pos == len(num) {
+			return 0, 0, false, false
+		}
+
+		e := 0
+		for ; pos < len(num) && num[pos] >= '0' && num[pos] <= '9'; pos++ {
+			if e > 10000 {
+				return 0, 0, false, false
+			}
+			e = e*10 + int(num[pos]-'0')
+		}
+
+		if expNeg {
+			e = -e
+		}
+		exp += e
+	}
+
+	if pos != len(num) {
+		return 0, 0, false, false
+	}
+
+	return mantissa, exp, neg, true
+}
+
+// parseFloat64 uses the exact fast path when the mantissa and the power of ten are both
+// exactly representable (Clinger), strconv otherwise which implements Eisel-Lemire
+// with an exact fallback. The bytes are never copied
+func parseFloat64(num []byte) (float64, error) {
+	mantissa, exp, neg, ok := parseDecimal(num)
+	if ok && mantissa <= 1<<53 && exp >= -22 && exp <= 22 {
+		f := float64(mantissa)
+		if exp > 0 {
+			f *= float64pow10[exp]
+		} else if exp < 0 {
+			f /= float64pow10[-exp]
+		}
+		if neg {
+			f = -f
+		}
+		return f, nil
+	}
+
+	f, err := strconv.ParseFloat(unsafe.String(unsafe.SliceData(num), len(num)), 64)
+	if err != nil {
+		return -1, ERROR_INVALID_FLOAT
+	}
+	return f, nil
+}
+
+// parseFloat32 is parseFloat64 for single precision
+func parseFloat32(num []byte) (float32, error) {
+	mantissa, exp, neg, ok := parseDecimal(num)
+	if ok && mantissa <= 1<<24 && exp >= -10 && exp <= 10 {
+		f := float32(mantissa)
+		if exp > 0 {
+			f *= float32pow10[exp]
+		} else if exp < 0 {
+			f /= float32pow10[-exp]
+		}
+		if neg {
+			f = -f
+		}
+		return f, nil
+	}
+
+	f, err := strconv.ParseFloat(unsafe.String(unsafe.SliceData(num), len(num)), 32)
+	if err != nil {
+		return -1, ERROR_INVALID_FLOAT
+	}
+	return float32(f), nil
+}
@@ -0,0 +1,86 @@
+package jsonparser
+
+import (
+	"database/sql"
+	"reflect"
+)
+
+// Optional holds a value that can be absent or null in the document
+type Optional[T any] struct {
+	Value   T
+	Present bool // the field path exists, even if its value is null
+	Null    bool // the value is the null literal
+}
+
+// Valid reports whether Value holds a decoded value
+func (o Optional[T]) Valid() bool {
+	return o.Present && !o.Null
+}
+
+// Or returns Value when valid, def otherwise
+func (o Optional[T]) Or(def T) T {
+	if o.Valid() {
+		return o.Value
+	}
+	return def
+}
+
+// API
+
+// GetOptional decodes the value at the field path like Get, telling apart a missing field,
+// a null value and a present one. Missing and null are not errors,
+// a value of a different type than T is reported as ERROR_TYPE_MISMATCH
+func GetOptional[T any](json []byte, fields ...string) (Optional[T], error) {
+	var res Optional[T]
+
+	valueSlice, kind, err := lookupValue(json, expectedType(reflect.TypeFor[T]()), fields...)
+	if err != nil || kind == TYPE_UNKNOWN {
+		return res, err
+	}
+
+	res.Present = true
+	if kind == TYPE_NULL {
+		res.Null = true
+		return res, nil
+	}
+
+	if err := decode(&res.Value, kind, valueSlice); err != nil {
+		return Optional[T]{}, err
+	}
+
+	return res, nil
+}
+
+// INTERNAL
+
+// scanValue decodes a value into a sql.Scanner such as sql.NullString or sql.NullInt64,
+// null leaves the target invalid
+func scanValue(scanner sql.Scanner, kind ValueType, slice []byte) error {
+	switch kind {
+	case TYPE_NULL:
+		return scanner.Scan(nil)
+
+	case TYPE_STRING:
+		return scanner.Scan(string(slice))
+
+	case TYPE_BOOLEAN:
+		boolean, err := ParseBool(slice)
+		if err != nil {
+			return err
+		}
+		return scanner.Scan(boolean)
+
+	case TYPE_NUMBER:
+		if int64Val, err := ParseInt64(slice); err == nil {
+			return scanner.Scan(int64Val)
+		}
+
+		float64Val, err := ParseFloat64(slice)
+		if err != nil {
+			return err
+		}
+		return scanner.Scan(float64Val)
+	}
+
+	return ERROR_TYPE_MISMATCH
+}
@@ -0,0 +1,254 @@
+package jsonparser
+
+import (
+	"io"
+	"slices"
+	"strings"
+)
+
+// Partial is a truncated document made valid by Recover
+type Partial struct {
+	JSON      []byte          // the recovered document, usable with every getter
+	Truncated [][]PathSegment // paths of the values that were cut, outermost first, the document itself has an empty path
+}
+
+// IsTruncated reports whether the value at the field path was cut. The members of a truncated
+// object that were read completely are not truncated, the members lost entirely are missing
+func (p Partial) IsTruncated(fields ...string) bool {
+	for _, path := range p.Truncated {
+		if slices.EqualFunc(path, fields, func(segment PathSegment, field string) bool {
+			return segment.String() == field
+		}) {
+			return true
+		}
+	}
+	return false
+}
+
+// API
+
+// Recover completes a document cut at any point, as streamed outputs often are: open strings
+// are closed, partial literals and numbers are completed or dropped, incomplete members are
+// removed and open arrays and objects are closed. A complete document is returned unchanged.
+// Input that is invalid before its end is not recovered and its error is returned
+func Recover(json []byte) (Partial, error) {
+	r := recovery{tokenizer: Tokenizer{json: json}}
+	return r.recover()
+}
+
+// GetPartial returns the raw value at the field path of a document that may be truncated.
+// A value cut by the end of json is completed as Recover does and truncated is true
+func GetPartial(json []byte, fields ...string) (value []byte, truncated bool, err error) {
+	pos, err := findValuePos(json, fields...)
+	if err != nil {
+		return nil, false, err
+	}
+	pos = skipWhitespace(json, pos)
+	if pos >= len(json) {
+		return nil, false, ERROR_FIELD_NOT_FOUND // cut before the value
+	}
+
+	end, err := valueEnd(json, pos)
+	switch {
+	case err == nil:
+		if end == len(json) && valueType(json, pos) == TYPE_NUMBER {
+			return json[pos:end], true, nil // it may have lost digits
+		}
+		return json[pos:end], false, nil
+
+	case err == ERROR_UNTERMINATED_ARRAY, err == ERROR_INVALID_JSON, err == ERROR_INVALID_FLOAT,
+		err == ERROR_INVALID_BOOLEAN, err == ERROR_INVALID_NULL:
+		// cut by the end of the input if what follows pos is the beginning of a document
+		p, err := Recover(json[pos:])
+		if err != nil {
+			return nil, false, err
+		}
+		return p.JSON, len(p.Truncated) > 0, nil
+	}
+
+	return nil, false, err
+}
+
+// INTERNAL
+
+type recovery struct {
+	tokenizer Tokenizer
+	stack     []recoveryFrame
+	key       string        // key of the next member
+	safe      int           // end of the last token after which the document can be closed
+	last      []PathSegment // path of the last number
+	lastEnd   int           // end of the last number
+}
+
+type recoveryFrame struct {
+	path  []PathSegment
+	array bool
+	count int
+}
+
+func (r *recovery) recover() (Partial, error) {
+	json := r.tokenizer.json
+
+	for {
+		token, err := r.tokenizer.Next()
+		if err == io.EOF {
+			return Partial{JSON: json}, nil
+		}
+		if err != nil {
+			return r.complete(err)
+		}
+
+		switch token.Kind {
+		case TOKEN_KEY:
+			r.key = string(token.Value)
+			continue
+
+		case TOKEN_OBJECT_START, TOKEN_ARRAY_START:
+			r.stack = append(r.stack, recoveryFrame{path: r.nextPath(), array: token.Kind == TOKEN_ARRAY_START})
+
+		case TOKEN_OBJECT_END, TOKEN_ARRAY_END:
+			r.stack = r.stack[:len(r.stack)-1]
+
+		case TOKEN_NUMBER:
+			r.last, r.lastEnd = r.nextPath(), token.End
+
+		default:
+			r.nextPath()
+		}
+
+		r.safe = token.End
+	}
+}
+
+// complete closes the document at the point where the tokenizer stopped
+func (r *recovery) complete(err error) (Partial, error) {
+	json := r.tokenizer.json
+	pos := skipWhitespace(json, r.tokenizer.Offset())
+
+	if len(r.stack) == 0 && r.safe == 0 && pos >= len(json) {
+		return Partial{}, ERROR_INVALID_JSON // nothing to recover
+	}
+
+	var res []byte
+	var truncated []PathSegment
+
+	switch {
+	case pos >= len(json):
+		// cut between tokens, after a comma, a key or a colon
+		res = append(res, json[:r.safe]...)
+		if r.lastEnd == len(json) && r.safe == len(json) {
+			truncated = r.last // a number at the end may have lost digits
+		}
+
+	case r.tokenizer.expect == expectValue || r.tokenizer.expect == expectFirstValue:
+		suffix, ok := completeToken(json[pos:])
+		if !ok {
+			return Partial{}, err
+		}
+		if suffix == nil {
+			res = append(res, json[:r.safe]...) // nothing left of the value, e.g. a lone "-"
+			break
+		}
+		truncated = r.nextPath()
+		res = append(append(res, json[:pos]...), suffix...)
+
+	case r.tokenizer.expect == expectKey || r.tokenizer.expect == expectFirstKey:
+		if json[pos] != '"' || err != ERROR_INVALID_JSON {
+			return Partial{}, err
+		}
+		res = append(res, json[:r.safe]...) // the key is cut, the member is dropped
+
+	case err == ERROR_COLON_NOT_FOUND && r.tokenizer.Offset() >= len(json):
+		res = append(res, json[:r.safe]...)
+
+	default:
+		return Partial{}, err
+	}
+
+	if len(res) == 0 {
+		return Partial{}, ERROR_INVALID_JSON
+	}
+
+	p := Partial{}
+	for i := len(r.stack) - 1; i >= 0; i-- {
+		if r.stack[i].array {
+			res = append(res, ']')
+		} else {
+			res = append(res, '}')
+		}
+	}
+	for _, frame := range r.stack {
+		p.Truncated = append(p.Truncated, frame.path)
+	}
+	if truncated != nil {
+		p.Truncated = append(p.Truncated, truncated)
+	}
+
+	p.JSON = res
+	return p, nil
+}
+
+// nextPath returns the path of the value starting now and counts it in its array
+func (r *recovery) nextPath() []PathSegment {
+	if len(r.stack) == 0 {
+		return []PathSegment{}
+	}
+
+	top := &r.stack[len(r.stack)-1]
+	path := slices.Clip(top.path)
+
+	if top.array {
+		top.count++
+		return append(path, PathSegment{Index: top.count - 1})
+	}
+	return append(path, PathSegment{Key: r.key, Index: -1})
+}
+
+// completeToken completes a value cut by the end of the input, it returns nil when nothing can be kept
+// and ok is false if rest is not the beginning of a value
+func completeToken(rest []byte) ([]byte, bool) {
+	switch rest[0] {
+	case '"':
+		content := rest[1:]
+		for i := 0; i < len(content); i++ {
+			if content[i] != '\\' {
+				continue
+			}
+			// an escape cut in the middle is dropped
+			if i+1 >= len(content) || content[i+1] == 'u' && i+6 > len(content) {
+				content = content[:i]
+				break
+			}
+			if content[i+1] == 'u' {
+				i += 5
+			} else {
+				i++
+			}
+		}
+		return append(append([]byte{'"'}, content...), '"'), true
+
+	case 't', 'f', 'n':
+		for _, literal := range []string{"true", "false", "null"} {
+			if strings.HasPrefix(literal, string(rest)) {
+				return []byte(literal), true
+			}
+		}
+		return nil, false
+
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		for _, c := range rest {
+			if strings.IndexByte("-+.eE0123456789", c) < 0 {
+				return nil, false
+			}
+		}
+		// drop the trailing characters that leave the number invalid, "1.", "2e-"
+		for n := len(rest); n > 0; n-- {
+			if isValidNumber(rest[:n]) {
+				return rest[:n], true
+			}
+		}
+		return nil, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,440 @@
+package jsonparser
+
+import (
+	"bytes"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// patchOp is an operation of a JSON Patch, paths are already converted to fields
+type patchOp struct {
+	op    string
+	path  []string
+	from  []string
+	value []byte
+}
+
+// API
+
+// ApplyPatch applies a JSON Patch (RFC 6902) to doc: add, remove, replace, move, copy and test,
+// addressed by JSON Pointer. Every operation edits the bytes of the document as Set and Delete do,
+// no tree is built and the formatting is kept. The patch is atomic: on any failure, including
+// a failed test, doc is returned unchanged with the error
+func ApplyPatch(doc, patch []byte) ([]byte, error) {
+	ops, err := parsePatch(patch)
+	if err != nil {
+		return doc, err
+	}
+
+	// Set and Delete return copies, doc is never modified
+	res := doc
+	for _, op := range ops {
+		if res, err = op.apply(res); err != nil {
+			return doc, err
+		}
+	}
+
+	return res, nil
+}
+
+// INTERNAL
+
+func parsePatch(patch []byte) ([]patchOp, error) {
+	v, err := Parse(patch)
+	if err != nil {
+		return nil, err
+	}
+	if v.kind != TYPE_ARRAY {
+		return nil, ERROR_INVALID_PATCH
+	}
+
+	var ops []patchOp
+	_, err = arrayEach(v.raw, 0, func(_, start, end int) error {
+		if v.raw[start] != '{' {
+			return ERROR_INVALID_PATCH
+		}
+
+		var op patchOp
+		var hasPath, hasFrom bool
+		_, err := objectEach(v.raw, start, func(key []byte, start, end int) error {
+			var err error
+			switch string(key) {
+			case "op":
+				op.op, err = patchString(v.raw[start:end])
+			case "path":
+				op.path, err = patchPointer(v.raw[start:end])
+				hasPath = true
+			case "from":
+				op.from, err = patchPointer(v.raw[start:end])
+				hasFrom = true
+			case "value":
+				op.value = v.raw[start:end]
+			}
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		switch op.op {
+		case "add", "replace", "test":
+			if op.value == nil {
+				return ERROR_INVALID_PATCH
+			}
+		case "move", "copy":
+			if !hasFrom {
+				return ERROR_INVALID_PATCH
+			}
+		case "remove":
+		default:
+			return ERROR_INVALID_PATCH
+		}
+		if !hasPath {
+			return ERROR_INVALID_PATCH
+		}
+
+		ops = append(ops, op)
+		return nil
+	})
+
+	return ops, err
+}
+
+// patchString returns the content of the JSON string raw
+func patchString(raw []byte) (string, error) {
+	if raw[0] != '"' {
+		return "", ERROR_INVALID_PATCH
+	}
+
+	s, err := unescapeString(raw[1 : len(raw)-1])
+	return string(s), err
+}
+
+// patchPointer returns the fields of the JSON Pointer in the JSON string raw
+func patchPointer(raw []byte) ([]string, error) {
+	pointer, err := patchString(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePointer(pointer)
+}
+
+func (op patchOp) apply(json []byte) ([]byte, error) {
+	if err := checkIndexes(json, op.path); err != nil {
+		return nil, err
+	}
+	if err := checkIndexes(json, op.from); err != nil {
+		return nil, err
+	}
+
+	switch op.op {
+	case "add":
+		return addValue(json, op.value, op.path)
+
+	case "remove":
+		return Delete(json, op.path...)
+
+	case "replace":
+		if _, err := patchValue(json, op.path); err != nil {
+			return nil, err
+		}
+		return Set(json, op.value, op.path...)
+
+	case "move":
+		value, err := patchValue(json, op.from)
+		if err != nil {
+			return nil, err
+		}
+		if isPathPrefix(op.from, op.path) {
+			if len(op.from) == len(op.path) {
+				return json, nil
+			}
+			return nil, ERROR_INVALID_PATCH // a value can not be moved into itself
+		}
+
+		res, err := Delete(json, op.from...)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(res, value, op.path)
+
+	case "copy":
+		value, err := patchValue(json, op.from)
+		if err != nil {
+			return nil, err
+		}
+		return addValue(json, value, op.path)
+
+	case "test":
+		value, err := patchValue(json, op.path)
+		if err != nil {
+			return nil, err
+		}
+
+		equal, err := jsonEqual(value, op.value)
+		if err != nil {
+			return nil, err
+		}
+		if !equal {
+			return nil, ERROR_TEST_FAILED
+		}
+		return json, nil
+	}
+
+	return nil, ERROR_INVALID_PATCH
+}
+
+// checkIndexes fails with ERROR_INVALID_POINTER if an array index of fields has a leading zero,
+// RFC 6901 forbids it. In an object such a token is a key like any other
+func checkIndexes(json []byte, fields []string) error {
+	for i, field := range fields {
+		if len(field) < 2 || field[0] != '0' || !isNumericField(field) {
+			continue
+		}
+
+		// a missing container is reported by the operation
+		start, _, missing, err := locate(json, escapeFields(fields[:i]))
+		if err == nil && missing == i && json[start] == '[' {
+			return ERROR_INVALID_POINTER
+		}
+	}
+	return nil
+}
+
+// patchValue returns the raw value at fields, which must exist
+func patchValue(json []byte, fields []string) ([]byte, error) {
+	start, end, missing, err := locate(json, escapeFields(fields))
+	if err != nil {
+		return nil, err
+	}
+	if missing != len(fields) {
+		return nil, ERROR_FIELD_NOT_FOUND
+	}
+
+	return json[start:end], nil
+}
+
+// addValue is the add operation: the parent of fields must exist, in an array the value
+// is inserted before the element at the index, "-" or the length of the array appends it,
+// in an object the member is created or replaced
+func addValue(json []byte, value []byte, fields []string) ([]byte, error) {
+	if len(fields) == 0 {
+		return Set(json, value)
+	}
+
+	parent := fields[:len(fields)-1]
+	start, end, missing, err := locate(json, escapeFields(parent))
+	if err != nil {
+		return nil, err
+	}
+	if missing != len(parent) {
+		return nil, ERROR_FIELD_NOT_FOUND
+	}
+
+	last := fields[len(fields)-1]
+	if json[start] != '[' {
+		return Set(json, value, fields...)
+	}
+
+	index := -1
+	if last != "-" {
+		if !isNumericField(last) {
+			return nil, ERROR_TYPE_MISMATCH
+		}
+		if index, err = strconv.Atoi(last); err != nil {
+			return nil, err
+		}
+	}
+
+	count, elementStart := 0, -1
+	_, err = arrayEach(json, start, func(i, s, _ int) error {
+		if i == index {
+			elementStart = s
+			return errStop
+		}
+		count++
+		return nil
+	})
+	if err != nil && err != errStop {
+		return nil, err
+	}
+
+	if elementStart < 0 {
+		if index >= 0 && index != count {
+			return nil, ERROR_FIELD_NOT_FOUND
+		}
+		return Set(json, value, append(parent[:len(parent):len(parent)], strconv.Itoa(count))...)
+	}
+
+	v, err := Parse(value)
+	if err != nil {
+		return nil, err
+	}
+
+	// the new element is separated from the one it precedes as the elements of the array are
+	indent, _, _ := containerStyle(json, start, end)
+	element := append(append(bytes.Clone(v.raw), ','), indent...)
+	return splice(json, elementStart, elementStart, element), nil
+}
+
+// jsonEqual compares two JSON values as the test operation does: objects by their members in any
+// order, strings after unescaping and numbers by their exact value
+func jsonEqual(a, b []byte) (bool, error) {
+	a, b = bytes.TrimSpace(a), bytes.TrimSpace(b)
+	if len(a) == 0 || len(b) == 0 {
+		return false, ERROR_INVALID_JSON
+	}
+
+	kind := valueType(a, 0)
+	if kind != valueType(b, 0) {
+		return false, nil
+	}
+
+	switch kind {
+	case TYPE_OBJECT:
+		type member struct{ key, value []byte }
+		var members []member
+		if _, err := objectEach(a, 0, func(key []byte, start, end int) error {
+			members = append(members, member{key, a[start:end]})
+			return nil
+		}); err != nil {
+			return false, err
+		}
+
+		count := 0
+		equal := true
+		_, err := objectEach(b, 0, func(key []byte, start, end int) error {
+			count++
+			for _, m := range members {
+				if bytes.Equal(m.key, key) {
+					eq, err := jsonEqual(m.value, b[start:end])
+					equal = equal && eq
+					return err
+				}
+			}
+			equal = false
+			return nil
+		})
+		return equal && count == len(members), err
+
+	case TYPE_ARRAY:
+		var elements [][]byte
+		if _, err := arrayEach(a, 0, func(_, start, end int) error {
+			elements = append(elements, a[start:end])
+			return nil
+		}); err != nil {
+			return false, err
+		}
+
+		count := 0
+		equal := true
+		_, err := arrayEach(b, 0, func(i, start, end int) error {
+			count++
+			if i >= len(elements) {
+				equal = false
+				return errStop
+			}
+			eq, err := jsonEqual(elements[i], b[start:end])
+			equal = equal && eq
+			return err
+		})
+		if err == errStop {
+			err = nil
+		}
+		return equal && count == len(elements), err
+
+	case TYPE_STRING:
+		sa, err := unescapeString(a[1 : len(a)-1])
+		if err != nil {
+			return false, err
+		}
+		sb, err := unescapeString(b[1 : len(b)-1])
+		if err != nil {
+			return false, err
+		}
+		return bytes.Equal(sa, sb), nil
+
+	case TYPE_NUMBER:
+		if bytes.Equal(a, b) {
+			return true, nil
+		}
+		// compared exactly, as float64 9007199254740993 would equal 9007199254740992
+		if !isValidNumber(a) || !isValidNumber(b) {
+			return false, nil
+		}
+		ra, errA := Number(a).Rat()
+		rb, errB := Number(b).Rat()
+		return errA == nil && errB == nil && ra.Cmp(rb) == 0, nil
+	}
+
+	return bytes.Equal(a, b), nil
+}
+
+// unescapeString decodes the escapes of the content of a JSON string, it is returned as it is without escapes
+func unescapeString(content []byte) ([]byte, error) {
+	if bytes.IndexByte(content, '\\') < 0 {
+		return content, nil
+	}
+
+	res := make([]byte, 0, len(content))
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if c != '\\' {
+			res = append(res, c)
+			continue
+		}
+
+		if i+1 >= len(content) {
+			return nil, ERROR_INVALID_STRING
+		}
+		i++
+
+		switch content[i] {
+		case '"', '\\', '/':
+			res = append(res, content[i])
+		case 'b':
+			res = append(res, '\b')
+		case 'f':
+			res = append(res, '\f')
+		case 'n':
+			res = append(res, '\n')
+		case 'r':
+			res = append(res, '\r')
+		case 't':
+			res = append(res, '\t')
+		case 'u':
+			r, ok := hexRune(content, i+1)
+			if !ok {
+				return nil, ERROR_INVALID_STRING
+			}
+			i += 4
+
+			// a surrogate pair is written as two escapes
+			if utf16.IsSurrogate(r) {
+				if low, ok := hexRune(content, i+3); ok && i+2 < len(content) && content[i+1] == '\\' && content[i+2] == 'u' {
+					if decoded := utf16.DecodeRune(r, low); decoded != utf8.RuneError {
+						r = decoded
+						i += 6
+					}
+				}
+			}
+			res = utf8.AppendRune(res, r)
+		default:
+			return nil, ERROR_INVALID_STRING
+		}
+	}
+
+	return res, nil
+}
+
+// hexRune parses the 4 hex digits of a \u escape starting at pos
+func hexRune(content []byte, pos int) (rune, bool) {
+	if pos+4 > len(content) {
+		return 0, false
+	}
+
+	n, err := strconv.ParseUint(string(content[pos:pos+4]), 16, 16)
+	return rune(n), err == nil
+}
@@ -0,0 +1,61 @@
+package jsonparser
+
+import "strings"
+
+// pointerUnescaper decodes the tokens in a single pass, so "~01" gives "~1" and not "/"
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// API
+
+// ParsePointer splits a JSON Pointer (RFC 6901) into its reference tokens with "~1" and "~0"
+// decoded, e.g. "/a~1b/0" gives ["a/b", "0"]. The empty pointer is the whole document and gives no tokens
+func ParsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if pointer[0] != '/' {
+		return nil, ERROR_INVALID_POINTER
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		if !strings.Contains(token, "~") {
+			continue
+		}
+
+		for j := 0; j < len(token); j++ {
+			if token[j] == '~' && (j+1 >= len(token) || token[j+1] != '0' && token[j+1] != '1') {
+				return nil, ERROR_INVALID_POINTER
+			}
+		}
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+
+	return tokens, nil
+}
+
+// INTERNAL
+
+// appendEscaped appends s to dst with the escapes a JSON string needs, without the quotes
+func appendEscaped(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			dst = append(dst, '\\', c)
+		case c == '\n':
+			dst = append(dst, '\\', 'n')
+		case c == '\r':
+			dst = append(dst, '\\', 'r')
+		case c == '\t':
+			dst = append(dst, '\\', 't')
+		case c < 0x20:
+			dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,373 @@
+package jsonparser
+
+import (
+	"strconv"
+	"unsafe"
+)
+
+// PushParser parses a document fed in chunks of any size, e.g. network frames, and calls the
+// subscribed callbacks as soon as each subscribed value is complete. Only the values being
+// captured for a subscription and the current key or number are buffered, never the document
+type PushParser struct {
+	subs     []subscription
+	stack    []pushFrame
+	expect   expectation
+	lex      lexState
+	literal  string // expected literal while lexLiteral
+	litPos   int
+	escaped  bool
+	isKey    bool
+	number   []byte // number being read
+	captures []capture
+	buf      []byte // bytes of the values being captured, nested captures share it
+	path     []PathSegment
+	offset   int
+	err      error
+}
+
+type subscription struct {
+	fields   []string
+	indexes  []int // fields as array indexes, -1 when not numeric
+	callback func(path []PathSegment, value []byte) error
+}
+
+type pushFrame struct {
+	array bool
+	key   []byte // key of the current member
+	index int    // index of the current element
+}
+
+// capture is a subscribed value being read, it starts at buf[start] and ends when the stack is back to depth
+type capture struct {
+	start int
+	depth int
+	sub   int
+}
+
+type lexState int
+
+const (
+	lexNone lexState = iota
+	lexString
+	lexNumber
+	lexLiteral
+)
+
+// API
+
+func NewPushParser() *PushParser {
+	return &PushParser{}
+}
+
+// Subscribe registers callback for the value at the field path, "*" matches any key or index,
+// no fields subscribes to the whole document. The value is raw JSON, quotes included for strings.
+// The path and value alias internal buffers and are only valid during the callback.
+// An error from callback stops the parser and is returned by Feed or Close
+func (p *PushParser) Subscribe(callback func(path []PathSegment, value []byte) error, fields ...string) {
+	sub := subscription{fields: fields, indexes: make([]int, len(fields)), callback: callback}
+	for i, field := range fields {
+		sub.indexes[i] = -1
+		if isNumericField(field) {
+			sub.indexes[i], _ = strconv.Atoi(field)
+		}
+	}
+
+	p.subs = append(p.subs, sub)
+}
+
+// Feed parses the next chunk of the document, the chunk can be reused once Feed returns.
+// After an error every call returns it again
+func (p *PushParser) Feed(chunk []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	for i, c := range chunk {
+		if err := p.feed(c); err != nil {
+			p.offset += i
+			p.err = err
+			return err
+		}
+	}
+
+	p.offset += len(chunk)
+	return nil
+}
+
+// Close ends the document, it fails if the document is incomplete
+func (p *PushParser) Close() error {
+	if p.err != nil {
+		return p.err
+	}
+
+	if p.lex == lexNumber {
+		p.lex = lexNone
+		if err := p.numberDone(); err != nil {
+			p.err = err
+			return err
+		}
+	}
+
+	if p.lex != lexNone || p.expect != expectEOF {
+		p.err = ERROR_INVALID_JSON
+		return p.err
+	}
+
+	return nil
+}
+
+// Reset prepares the parser for a new document, the subscriptions are kept
+func (p *PushParser) Reset() {
+	*p = PushParser{subs: p.subs, stack: p.stack[:0], number: p.number[:0], captures: p.captures[:0], buf: p.buf[:0], path: p.path[:0]}
+}
+
+// Offset returns the number of bytes parsed, after an error the position of the malformed byte
+func (p *PushParser) Offset() int {
+	return p.offset
+}
+
+// INTERNAL
+
+func (p *PushParser) feed(c byte) error {
+	switch p.lex {
+	case lexString:
+		p.capture(c)
+
+		switch {
+		case p.escaped:
+			p.escaped = false
+		case c == '\\':
+			p.escaped = true
+		case c == '"':
+			p.lex = lexNone
+			if p.isKey {
+				p.expect = expectColon
+				return nil
+			}
+			return p.valueDone()
+		}
+
+		if p.isKey {
+			top := &p.stack[len(p.stack)-1]
+			top.key = append(top.key, c)
+		}
+		return nil
+
+	case lexLiteral:
+		if c != p.literal[p.litPos] {
+			return ERROR_INVALID_JSON
+		}
+
+		p.capture(c)
+		p.litPos++
+		if p.litPos == len(p.literal) {
+			p.lex = lexNone
+			return p.valueDone()
+		}
+		return nil
+
+	case lexNumber:
+		switch c {
+		case '-', '+', '.', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 'e', 'E':
+			p.capture(c)
+			p.number = append(p.number, c)
+			return nil
+		}
+
+		// c is the first byte after the number
+		p.lex = lexNone
+		if err := p.numberDone(); err != nil {
+			return err
+		}
+	}
+
+	return p.structural(c)
+}
+
+// structural handles a byte between tokens
+func (p *PushParser) structural(c byte) error {
+	if isWhitespace(c) {
+		p.capture(c)
+		return nil
+	}
+
+	switch p.expect {
+	case expectEOF:
+		return ERROR_INVALID_JSON
+
+	case expectColon:
+		if c != ':' {
+			return ERROR_COLON_NOT_FOUND
+		}
+		p.capture(c)
+		p.expect = expectValue
+		return nil
+
+	case expectSeparator:
+		top := &p.stack[len(p.stack)-1]
+		switch {
+		case c == ',':
+			p.capture(c)
+			p.expect = expectKey
+			if top.array {
+				top.index++
+				p.expect = expectValue
+			}
+			return nil
+		case c == '}' && !top.array, c == ']' && top.array:
+			return p.closeContainer(c)
+		}
+		return ERROR_INVALID_JSON
+
+	case expectFirstKey, expectKey:
+		if c == '}' && p.expect == expectFirstKey {
+			return p.closeContainer(c)
+		}
+		if c != '"' {
+			return ERROR_INVALID_JSON
+		}
+
+		p.capture(c)
+		top := &p.stack[len(p.stack)-1]
+		top.key = top.key[:0]
+		p.lex, p.isKey = lexString, true
+		return nil
+
+	case expectFirstValue:
+		if c == ']' {
+			return p.closeContainer(c)
+		}
+	}
+
+	return p.startValue(c)
+}
+
+func (p *PushParser) startValue(c byte) error {
+	p.openCaptures()
+	p.capture(c)
+
+	switch c {
+	case '{', '[':
+		p.push(c == '[')
+		p.expect = expectFirstKey
+		if c == '[' {
+			p.expect = expectFirstValue
+		}
+	case '"':
+		p.lex, p.isKey = lexString, false
+	case 't':
+		p.lex, p.literal, p.litPos = lexLiteral, "true", 1
+	case 'f':
+		p.lex, p.literal, p.litPos = lexLiteral, "false", 1
+	case 'n':
+		p.lex, p.literal, p.litPos = lexLiteral, "null", 1
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		p.lex = lexNumber
+		p.number = append(p.number[:0], c)
+	default:
+		return ERROR_INVALID_JSON
+	}
+
+	return nil
+}
+
+// push opens a container, the key buffers of previous frames are reused
+func (p *PushParser) push(array bool) {
+	if len(p.stack) < cap(p.stack) {
+		p.stack = p.stack[:len(p.stack)+1]
+	} else {
+		p.stack = append(p.stack, pushFrame{})
+	}
+
+	top := &p.stack[len(p.stack)-1]
+	top.array, top.key, top.index = array, top.key[:0], 0
+}
+
+func (p *PushParser) closeContainer(c byte) error {
+	p.capture(c)
+	p.stack = p.stack[:len(p.stack)-1]
+	return p.valueDone()
+}
+
+func (p *PushParser) numberDone() error {
+	if !isValidNumber(p.number) {
+		return ERROR_INVALID_FLOAT
+	}
+	return p.valueDone()
+}
+
+// valueDone completes the value at the current depth and fires the captures that end with it
+func (p *PushParser) valueDone() error {
+	for len(p.captures) > 0 {
+		last := p.captures[len(p.captures)-1]
+		if last.depth != len(p.stack) {
+			break
+		}
+
+		p.captures = p.captures[:len(p.captures)-1]
+		if err := p.subs[last.sub].callback(p.currentPath(), p.buf[last.start:]); err != nil {
+			return err
+		}
+	}
+
+	if len(p.captures) == 0 {
+		p.buf = p.buf[:0]
+	}
+
+	p.expect = expectSeparator
+	if len(p.stack) == 0 {
+		p.expect = expectEOF
+	}
+	return nil
+}
+
+// openCaptures starts a capture for every subscription matching the value starting now,
+// in reverse order so that they fire in subscription order
+func (p *PushParser) openCaptures() {
+	for i := len(p.subs) - 1; i >= 0; i-- {
+		if p.matches(&p.subs[i]) {
+			p.captures = append(p.captures, capture{start: len(p.buf), depth: len(p.stack), sub: i})
+		}
+	}
+}
+
+func (p *PushParser) matches(sub *subscription) bool {
+	if len(sub.fields) != len(p.stack) {
+		return false
+	}
+
+	for i, frame := range p.stack {
+		switch {
+		case sub.fields[i] == "*":
+		case frame.array:
+			if sub.indexes[i] != frame.index {
+				return false
+			}
+		default:
+			if sub.fields[i] != string(frame.key) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func (p *PushParser) capture(c byte) {
+	if len(p.captures) > 0 {
+		p.buf = append(p.buf, c)
+	}
+}
+
+// currentPath returns the path of the value completed at the current depth
+func (p *PushParser) currentPath() []PathSegment {
+	p.path = p.path[:0]
+	for _, frame := range p.stack {
+		if frame.array {
+			p.path = append(p.path, PathSegment{Index: frame.index})
+		} else {
+			p.path = append(p.path, PathSegment{Key: unsafe.String(unsafe.SliceData(frame.key), len(frame.key)), Index: -1})
+		}
+	}
+	return p.path
+}
@@ -0,0 +1,309 @@
+package jsonparser
+
+import (
+	"bytes"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// API
+
+// Set returns a copy of json with the value at the field path replaced by value, a raw JSON value.
+// A numeric field is a key in an object and an index in an array. Missing object keys are created
+// together with the containers below them, an index equal to the length of the array appends to it.
+// The rest of the document is kept byte for byte, new members copy the whitespace of their
+// neighbours. No fields replaces the whole document. Keys are given unescaped, they are escaped
+// as in a JSON string to be matched and written
+func Set(json []byte, value []byte, fields ...string) ([]byte, error) {
+	v, err := Parse(value)
+	if err != nil {
+		return nil, err
+	}
+	value = v.raw
+	fields = escapeFields(fields)
+
+	// the value exists, it is replaced in place
+	if pos, err := findValuePosWs(json, fields...); err == nil {
+		end, err := valueEnd(json, pos)
+		if err != nil {
+			return nil, err
+		}
+		return splice(json, pos, end, value), nil
+	}
+
+	start, end, missing, err := locate(json, fields)
+	if err != nil {
+		return nil, err
+	}
+	if missing == len(fields) {
+		return splice(json, start, end, value), nil
+	}
+
+	return insert(json, start, end, fields[missing:], value)
+}
+
+// INTERNAL
+
+// locate follows the field path from the document root and returns the [start, end) range of
+// the deepest value found and the index of the first missing field, len(fields) if all exist.
+// A field is a key in an object, even if numeric, and an index in an array. A missing field
+// is reported only if it can be created: a key in an object or the index right after the last
+// element of an array
+func locate(json []byte, fields []string) (start, end, missing int, err error) {
+	start = skipWhitespace(json, 0)
+	if start >= len(json) {
+		return -1, -1, -1, ERROR_INVALID_JSON
+	}
+
+	end, err = valueEnd(json, start)
+	if err != nil {
+		return -1, -1, -1, err
+	}
+
+	for i, field := range fields {
+		found := false
+
+		switch {
+		case json[start] == '[' && isNumericField(field):
+			index, err := strconv.Atoi(field)
+			if err != nil {
+				return -1, -1, -1, err
+			}
+			count := 0
+			_, err = arrayEach(json, start, func(i, s, e int) error {
+				count++
+				if i == index {
+					start, end, found = s, e, true
+					return errStop
+				}
+				return nil
+			})
+			if err != nil && err != errStop {
+				return -1, -1, -1, err
+			}
+			if !found && index != count {
+				return -1, -1, -1, ERROR_FIELD_NOT_FOUND
+			}
+
+		case json[start] == '{':
+			_, err = objectEach(json, start, func(key []byte, s, e int) error {
+				if string(key) == field {
+					start, end, found = s, e, true
+					return errStop
+				}
+				return nil
+			})
+			if err != nil && err != errStop {
+				return -1, -1, -1, err
+			}
+
+		default:
+			return -1, -1, -1, ERROR_TYPE_MISMATCH
+		}
+
+		if !found {
+			return start, end, i, nil
+		}
+	}
+
+	return start, end, len(fields), nil
+}
+
+// fieldMatches reports whether field selects the member with key, nil in arrays, at index:
+// as in locate a field is compared to the key in objects and to the index in arrays
+func fieldMatches(field string, key []byte, index int) bool {
+	if key != nil {
+		return string(key) == field
+	}
+	if !isNumericField(field) {
+		return false
+	}
+
+	i, err := strconv.Atoi(field)
+	return err == nil && i == index
+}
+
+// insert adds value at the path fields, which starts with the missing member of the container in [start, end)
+func insert(json []byte, start, end int, fields []string, value []byte) ([]byte, error) {
+	indent, colon, last := containerStyle(json, start, end)
+
+	member, err := newMember(json[start] == '{', indent, colon, last > 0, fields[0], [][]string{fields[1:]}, [][]byte{value})
+	if err != nil {
+		return nil, err
+	}
+
+	if last == 0 {
+		last = end - 1 // empty container, the member goes before the closing bracket
+	}
+	return splice(json, last, last, member), nil
+}
+
+// newMember builds the member of an object or array with key, its key or index, holding the values
+// at the paths below it. The containers on the paths are created by newValue
+func newMember(object bool, indent, colon []byte, comma bool, key string, paths [][]string, values [][]byte) ([]byte, error) {
+	value, err := newValue(colon, paths, values)
+	if err != nil {
+		return nil, err
+	}
+
+	var member []byte
+	if comma {
+		member = append(member, ',')
+	}
+	member = append(member, indent...)
+	if object {
+		member = append(append(append(append(member, '"'), key...), '"'), colon...)
+	}
+	return append(member, value...), nil
+}
+
+// newValue builds the value holding values at the paths relative to it, no path is the value itself.
+// The containers are created, an array only for the index 0, and the paths sharing their first
+// field go in the same member
+func newValue(colon []byte, paths [][]string, values [][]byte) ([]byte, error) {
+	if len(paths) == 1 && len(paths[0]) == 0 {
+		return values[0], nil
+	}
+	for _, path := range paths {
+		if len(path) == 0 {
+			return nil, ERROR_EDIT_CONFLICT // a value and a path inside it
+		}
+	}
+
+	object := !isNumericField(paths[0][0])
+	value := []byte{'['}
+	if object {
+		value[0] = '{'
+	}
+
+	// the members after the first are spaced as the colon
+	var indent []byte
+	if isWhitespace(colon[len(colon)-1]) {
+		indent = []byte{' '}
+	}
+
+	done := make([]bool, len(paths))
+	for i, path := range paths {
+		if done[i] {
+			continue
+		}
+
+		var group [][]string
+		var groupValues [][]byte
+		for j := i; j < len(paths); j++ {
+			if !done[j] && paths[j][0] == path[0] {
+				group = append(group, paths[j][1:])
+				groupValues = append(groupValues, values[j])
+				done[j] = true
+			}
+		}
+
+		if !object && path[0] != "0" {
+			if !isNumericField(path[0]) {
+				return nil, ERROR_TYPE_MISMATCH
+			}
+			return nil, ERROR_FIELD_NOT_FOUND
+		}
+
+		comma := len(value) > 1
+		memberIndent := indent
+		if !comma {
+			memberIndent = nil
+		}
+		member, err := newMember(object, memberIndent, colon, comma, path[0], group, groupValues)
+		if err != nil {
+			return nil, err
+		}
+		value = append(value, member...)
+	}
+
+	if object {
+		return append(value, '}'), nil
+	}
+	return append(value, ']'), nil
+}
+
+// containerStyle returns the whitespace before the last member of the container in [start, end),
+// the colon of its last member with the whitespace around it and the end of its last value,
+// 0 if the container is empty
+func containerStyle(json []byte, start, end int) (indent, colon []byte, last int) {
+	colon = []byte{':'}
+	prev := start + 1 // end of the previous separator
+	count := 0
+
+	memberEach(json, start, func(key []byte, member, s, e int) error {
+		indent, last = json[prev:member], e
+		if key != nil {
+			colon = json[member+len(key)+2 : s]
+		}
+		prev = skipWhitespace(json, e) + 1
+		count++
+		return nil
+	})
+
+	// a single member next to the bracket shows no separator, it is spaced as the colon
+	// of the member or, in an array, as the colon before the array
+	if count == 1 && len(indent) == 0 {
+		spacing := colon
+		if json[start] == '[' {
+			p := start - 1
+			for p >= 0 && isWhitespace(json[p]) {
+				p--
+			}
+			if p >= 0 && json[p] == ':' {
+				spacing = json[p:start]
+			}
+		}
+		if isWhitespace(spacing[len(spacing)-1]) {
+			indent = []byte{' '}
+		}
+	}
+
+	return indent, colon, last
+}
+
+// memberEach calls fn for every member of the container starting at pos with its key, nil in arrays,
+// the start of the member, the opening quote of the key in objects, and the [start, end) range of its value
+func memberEach(json []byte, pos int, fn func(key []byte, member, start, end int) error) error {
+	if json[pos] == '[' {
+		_, err := arrayEach(json, pos, func(_, start, end int) error {
+			return fn(nil, start, start, end)
+		})
+		return err
+	}
+
+	_, err := objectEach(json, pos, func(key []byte, start, end int) error {
+		member := bytes.LastIndexByte(json[:start], ':')
+		for json[member] != '"' {
+			member--
+		}
+		return fn(key, member-len(key)-1, start, end)
+	})
+	return err
+}
+
+// escapeFields returns fields with the keys escaped as they are written in a JSON string,
+// fields is copied only if a key needs escapes
+func escapeFields(fields []string) []string {
+	copied := false
+	for i, field := range fields {
+		if !strings.ContainsFunc(field, func(r rune) bool { return r == '"' || r == '\\' || r < 0x20 }) {
+			continue
+		}
+
+		if !copied {
+			fields, copied = slices.Clone(fields), true
+		}
+		fields[i] = string(appendEscaped(nil, field))
+	}
+	return fields
+}
+
+// splice returns a copy of json with [start, end) replaced by value
+func splice(json []byte, start, end int, value []byte) []byte {
+	res := make([]byte, 0, len(json)-(end-start)+len(value))
+	res = append(res, json[:start]...)
+	res = append(res, value...)
+	return append(res, json[end:]...)
+}
@@ -0,0 +1,397 @@
+package jsonparser
+
+import (
+	"bytes"
+	"io"
+	"strconv"
+)
+
+const streamBufferSize = 64 * 1024
+
+// StreamDecoder reads the elements of one array of a document from an io.Reader,
+// without loading the whole document. The buffer only grows to hold the largest
+// element (or key on the path), the values around the array are skipped as they are read
+type StreamDecoder struct {
+	streamReader
+	fields []string
+	found  bool // the array start has been read
+	index  int  // elements returned so far
+	err    error
+}
+
+// streamReader buffers an io.Reader for scanning values, consumed data is dropped on refill
+type streamReader struct {
+	r     io.Reader
+	buf   []byte
+	start int // unread data, buf[start:end]
+	end   int
+	eof   bool
+}
+
+// API
+
+// NewStreamDecoder returns a decoder for the elements of the array at the field path,
+// no fields means the document itself is the array
+func NewStreamDecoder(r io.Reader, fields ...string) *StreamDecoder {
+	return &StreamDecoder{streamReader: newStreamReader(r), fields: fields}
+}
+
+// Next returns the next element of the array as raw JSON, quotes included for strings.
+// The slice aliases the internal buffer and is only valid until the next call.
+// It returns io.EOF after the last element, then every error is returned again
+func (d *StreamDecoder) Next() ([]byte, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	value, err := d.next()
+	if err != nil {
+		d.err = err
+		return nil, err
+	}
+
+	d.index++
+	return value, nil
+}
+
+// Index returns the number of elements returned by Next so far
+func (d *StreamDecoder) Index() int {
+	return d.index
+}
+
+// DecodeNext decodes the next element of the array into value the way Get does,
+// it returns io.EOF after the last element
+func DecodeNext[T any](d *StreamDecoder, value *T) error {
+	raw, err := d.Next()
+	if err != nil {
+		return err
+	}
+
+	valueSlice, err := extractValue(raw, 0)
+	if err != nil {
+		return err
+	}
+
+	return decode(value, valueType(raw, 0), valueSlice)
+}
+
+// INTERNAL
+
+func (d *StreamDecoder) next() ([]byte, error) {
+	if !d.found {
+		if err := d.seek(); err != nil {
+			return nil, err
+		}
+		d.found = true
+	}
+
+	c, err := d.peek()
+	if err != nil {
+		return nil, err
+	}
+
+	if c == ']' {
+		d.start++
+		return nil, io.EOF
+	}
+
+	if d.index > 0 {
+		if c != ',' {
+			return nil, ERROR_INVALID_ARRAY
+		}
+		d.start++
+
+		if _, err := d.peek(); err != nil {
+			return nil, err
+		}
+	}
+
+	return d.readValue()
+}
+
+// seek reads the document up to the start of the array at the field path
+func (d *StreamDecoder) seek() error {
+	for _, field := range d.fields {
+		c, err := d.peek()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case c == '{':
+			err = d.seekKey(field)
+		case c == '[' && isNumericField(field):
+			err = d.seekIndex(field)
+		default:
+			err = ERROR_FIELD_NOT_FOUND
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	c, err := d.peek()
+	if err != nil {
+		return err
+	}
+	if c != '[' {
+		return ERROR_TYPE_MISMATCH
+	}
+
+	d.start++
+	return nil
+}
+
+// seekKey moves to the value of the member key of the object starting at the current position
+func (d *StreamDecoder) seekKey(key string) error {
+	d.start++ // '{'
+
+	for first := true; ; first = false {
+		c, err := d.peek()
+		if err != nil {
+			return err
+		}
+
+		if c == '}' {
+			return ERROR_FIELD_NOT_FOUND
+		}
+
+		if !first {
+			if c != ',' {
+				return ERROR_INVALID_JSON
+			}
+			d.start++
+			if c, err = d.peek(); err != nil {
+				return err
+			}
+		}
+
+		if c != '"' {
+			return ERROR_INVALID_JSON
+		}
+
+		raw, err := d.readValue()
+		if err != nil {
+			return err
+		}
+		match := string(raw[1:len(raw)-1]) == key
+
+		if c, err = d.peek(); err != nil {
+			return err
+		}
+		if c != ':' {
+			return ERROR_COLON_NOT_FOUND
+		}
+		d.start++
+
+		if match {
+			return nil
+		}
+
+		if err := d.skipValue(); err != nil {
+			return err
+		}
+	}
+}
+
+// seekIndex moves to the element at index of the array starting at the current position
+func (d *StreamDecoder) seekIndex(field string) error {
+	index, err := strconv.Atoi(field)
+	if err != nil {
+		return ERROR_ARGUMENTS
+	}
+
+	d.start++ // '['
+
+	for i := 0; ; i++ {
+		c, err := d.peek()
+		if err != nil {
+			return err
+		}
+
+		if c == ']' {
+			return ERROR_FIELD_NOT_FOUND
+		}
+
+		if i > 0 {
+			if c != ',' {
+				return ERROR_INVALID_ARRAY
+			}
+			d.start++
+			if _, err := d.peek(); err != nil {
+				return err
+			}
+		}
+
+		if i == index {
+			return nil
+		}
+
+		if err := d.skipValue(); err != nil {
+			return err
+		}
+	}
+}
+
+func newStreamReader(r io.Reader) streamReader {
+	return streamReader{r: r, buf: make([]byte, streamBufferSize)}
+}
+
+// skipValue skips the value at the current position, containers are skipped
+// as they are read so they never need to fit in the buffer
+func (d *streamReader) skipValue() error {
+	c, err := d.peek()
+	if err != nil {
+		return err
+	}
+
+	if c != '{' && c != '[' {
+		_, err := d.readValue()
+		return err
+	}
+
+	depth := 0
+	inString := false
+	escaped := false
+
+	for {
+		for d.start < d.end {
+			c := d.buf[d.start]
+			d.start++
+
+			if inString {
+				switch {
+				case escaped:
+					escaped = false
+				case c == '\\':
+					escaped = true
+				case c == '"':
+					inString = false
+				}
+				continue
+			}
+
+			switch c {
+			case '"':
+				inString = true
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+				if depth == 0 {
+					return nil
+				}
+			}
+		}
+
+		if d.eof {
+			return ERROR_INVALID_JSON
+		}
+		if err := d.fill(); err != nil {
+			return err
+		}
+	}
+}
+
+// readValue returns the whole value at the current position, reading until it is in the buffer.
+// A value cut by the end of the buffer is read again with more data, one that is invalid
+// before the end fails without reading further
+func (d *streamReader) readValue() ([]byte, error) {
+	for {
+		window := d.buf[d.start:d.end]
+
+		end, err := valueEnd(window, 0)
+		if err == nil && (end < len(window) || d.eof) {
+			d.start += end
+			return window[:end], nil
+		}
+		if err != nil && (d.eof || !isTruncated(window)) {
+			return nil, err
+		}
+
+		if err := d.fill(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// peek returns the next byte after whitespace without consuming it
+func (d *streamReader) peek() (byte, error) {
+	for {
+		d.start = skipWhitespace(d.buf[:d.end], d.start)
+		if d.start < d.end {
+			return d.buf[d.start], nil
+		}
+
+		if d.eof {
+			return 0, ERROR_INVALID_JSON
+		}
+		if err := d.fill(); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// readUntil returns the data up to the next sep, or up to the end of the input, without consuming sep
+func (d *streamReader) readUntil(sep byte) ([]byte, error) {
+	scanned := 0 // bytes after start already searched
+	for {
+		if i := bytes.IndexByte(d.buf[d.start+scanned:d.end], sep); i >= 0 {
+			res := d.buf[d.start : d.start+scanned+i]
+			d.start += scanned + i
+			return res, nil
+		}
+
+		if d.eof {
+			res := d.buf[d.start:d.end]
+			d.start = d.end
+			return res, nil
+		}
+
+		scanned = d.end - d.start
+		if err := d.fill(); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// fill moves the unread data to the front of the buffer, grows it when full, and reads once
+func (d *streamReader) fill() error {
+	if d.start > 0 {
+		d.end = copy(d.buf, d.buf[d.start:d.end])
+		d.start = 0
+	}
+
+	if d.end == len(d.buf) {
+		d.buf = append(d.buf, make([]byte, len(d.buf))...)
+	}
+
+	n, err := d.r.Read(d.buf[d.end:])
+	d.end += n
+
+	if err == io.EOF {
+		d.eof = true
+		return nil
+	}
+	return err
+}
+
+// isTruncated reports whether the value at the start of window, on which valueEnd failed,
+// may be cut by the end of window: strings and containers only fail there and literals when
+// window ends inside them. No value starts with any other byte
+func isTruncated(window []byte) bool {
+	pos := skipWhitespace(window, 0)
+	if pos >= len(window) {
+		return true
+	}
+
+	switch window[pos] {
+	case '"', '{', '[':
+		return true
+	case 't', 'f', 'n':
+		_, ok := completeToken(window[pos:])
+		return ok
+	}
+	return false
+}
@@ -1,8 +1,11 @@
 package benchmarks
 
 import (
+	"bytes"
 	"encoding/json"
+	"io"
 	"os"
+	"strconv"
 	"testing"
 
 	buger "github.com/buger/jsonparser"
@@ -47,6 +50,31 @@ func BenchmarkString_Get_Mucca(b *testing.B) {
 	}
 }
 
+func BenchmarkString_GetStringView_Mucca(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := jsonparser.GetStringView(comparisonJson, "stringValue")
+		if err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkString_AppendString_Mucca(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		var err error
+		if buf, err = jsonparser.AppendString(buf[:0], comparisonJson, "stringValue"); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
 func BenchmarkString_GetString_Buger(b *testing.B) {
 	b.ReportAllocs()
 	b.ResetTimer()
@@ -228,6 +256,88 @@ func BenchmarkFloat_GetFloat_Std(b *testing.B) {
 	}
 }
 
+// Benchmark number parsing from raw bytes
+var (
+	rawInt64   = []byte("-9223372036854775808")
+	rawFloat64 = []byte("1.7976931348623157e+308")
+	rawPrice   = []byte("19.99")
+)
+
+func BenchmarkParseInt64_Mucca(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := jsonparser.ParseInt64(rawInt64); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkParseInt64_Buger(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := buger.ParseInt(rawInt64); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkParseInt64_Std(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := strconv.ParseInt(string(rawInt64), 10, 64); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkParseFloat64_Mucca(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := jsonparser.ParseFloat64(rawFloat64); err != nil {
+			b.Error(err)
+		}
+		if _, err := jsonparser.ParseFloat64(rawPrice); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkParseFloat64_Buger(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := buger.ParseFloat(rawFloat64); err != nil {
+			b.Error(err)
+		}
+		if _, err := buger.ParseFloat(rawPrice); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
+func BenchmarkParseFloat64_Std(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := strconv.ParseFloat(string(rawFloat64), 64); err != nil {
+			b.Error(err)
+		}
+		if _, err := strconv.ParseFloat(string(rawPrice), 64); err != nil {
+			b.Error(err)
+		}
+	}
+}
+
 // Benchmark GetBool operations
 func BenchmarkBool_GetBool_Mucca(b *testing.B) {
 	b.ReportAllocs()
@@ -426,3 +536,108 @@ func BenchmarkArrayIteration_ArrayEach_Std(b *testing.B) {
 		}
 	}
 }
+
+// Benchmark tokenizing the whole document
+func BenchmarkTokenize_Tokenizer_Mucca(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		tokenizer := jsonparser.NewTokenizer(comparisonJson)
+		for {
+			_, err := tokenizer.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkTokenize_Decoder_Std(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		decoder := json.NewDecoder(bytes.NewReader(comparisonJson))
+		for {
+			_, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// Benchmark batch edits: three sets and two deletes
+func BenchmarkEdit_Editor_Mucca(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	buf := make([]byte, 0, 2*len(comparisonJson))
+	e := jsonparser.NewEditor()
+	for i := 0; i < b.N; i++ {
+		e.Reset()
+		e.Set([]byte(`"edited"`), "stringValue")
+		e.Set([]byte(`false`), "boolTrue")
+		e.Set([]byte(`7`), "intPositive")
+		e.Delete("longString")
+		e.Delete("nullValue")
+
+		var err error
+		if buf, err = e.Apply(buf[:0], comparisonJson); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEdit_SetDelete_Mucca(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		res, err := jsonparser.Set(comparisonJson, []byte(`"edited"`), "stringValue")
+		if err == nil {
+			res, err = jsonparser.Set(res, []byte(`false`), "boolTrue")
+		}
+		if err == nil {
+			res, err = jsonparser.Set(res, []byte(`7`), "intPositive")
+		}
+		if err == nil {
+			res, err = jsonparser.Delete(res, "longString")
+		}
+		if err == nil {
+			_, err = jsonparser.Delete(res, "nullValue")
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkEdit_SetDelete_Buger(b *testing.B) {
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		res, err := buger.Set(bytes.Clone(comparisonJson), []byte(`"edited"`), "stringValue")
+		if err == nil {
+			res, err = buger.Set(res, []byte(`false`), "boolTrue")
+		}
+		if err == nil {
+			res, err = buger.Set(res, []byte(`7`), "intPositive")
+		}
+		if err == nil {
+			res = buger.Delete(res, "longString")
+			_ = buger.Delete(res, "nullValue")
+		}
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
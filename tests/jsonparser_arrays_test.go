@@ -285,6 +285,26 @@ func TestSpecialCharactersArray(t *testing.T) {
 	}
 }
 
+// Test elements after strings ending with an escaped backslash or holding brackets
+func TestElementsAfterEscapedStrings(t *testing.T) {
+	tests := []struct {
+		json     string
+		index    string
+		expected int
+	}{
+		{`["a\\", 5]`, "1", 5},
+		{`["a\\\\", 5]`, "1", 5},
+		{`["\\", "[", 5]`, "2", 5},
+		{`["a\\", {"b": "\\"}, 5]`, "2", 5},
+	}
+
+	for _, test := range tests {
+		result, err := jsonparser.GetInt([]byte(test.json), test.index)
+		assert.NoError(t, err, "Error getting %s[%s]", test.json, test.index)
+		assert.Equal(t, test.expected, result, "%s[%s] should equal %d", test.json, test.index, test.expected)
+	}
+}
+
 // Test single element arrays
 func TestSingleElementArrays(t *testing.T) {
 	// Test single string
@@ -621,3 +641,19 @@ func BenchmarkForeachArrayElement_ArrayOfObjects(b *testing.B) {
 		}, "array_of_objects")
 	}
 }
+
+func TestNumericKeys(t *testing.T) {
+	// a numeric field is a key in an object and an index in an array
+	json := []byte(`{"2024": {"total": 5, "months": [{"1": "jan"}]}}`)
+
+	total, err := jsonparser.GetInt(json, "2024", "total")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, total)
+
+	month, err := jsonparser.GetString(json, "2024", "months", "0", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "jan", month)
+
+	assert.True(t, jsonparser.Exists(json, "2024", "months", "0"))
+	assert.False(t, jsonparser.Exists(json, "2024", "months", "1"))
+}
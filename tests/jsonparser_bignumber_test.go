@@ -0,0 +1,125 @@
+package jsonparser_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var financialJson = []byte(`{
+	"id": 123456789012345678901234567890,
+	"negative_id": -98765432109876543210,
+	"amount": 1234567890.123456789012345678,
+	"exponent": 1e30,
+	"fraction": 2.5e-3,
+	"small": 42,
+	"label": "123"
+}`)
+
+func TestGetNumber(t *testing.T) {
+	id, err := jsonparser.GetNumber(financialJson, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, jsonparser.Number("123456789012345678901234567890"), id)
+
+	bigInt, err := id.BigInt()
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789012345678901234567890", bigInt.String())
+
+	_, err = id.Int64()
+	assert.Equal(t, jsonparser.ERROR_INTEGER_OVERFLOW, err)
+
+	small, err := jsonparser.GetNumber(financialJson, "small")
+	assert.NoError(t, err)
+	smallInt, err := small.Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), smallInt)
+	smallUint, err := small.Uint64()
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(42), smallUint)
+
+	negative, err := jsonparser.GetNumber(financialJson, "negative_id")
+	assert.NoError(t, err)
+	_, err = negative.Uint64()
+	assert.Error(t, err)
+	negativeInt, err := negative.BigInt()
+	assert.NoError(t, err)
+	assert.Equal(t, "-98765432109876543210", negativeInt.String())
+
+	_, err = jsonparser.GetNumber(financialJson, "label")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	_, err = jsonparser.GetNumber([]byte(`{"a": 01}`), "a")
+	assert.Equal(t, jsonparser.ERROR_INVALID_FLOAT, err)
+}
+
+func TestNumber_Conversions(t *testing.T) {
+	amount, err := jsonparser.GetNumber(financialJson, "amount")
+	assert.NoError(t, err)
+
+	rat, err := amount.Rat()
+	assert.NoError(t, err)
+	expected, _ := new(big.Rat).SetString("1234567890123456789012345678/1000000000000000000")
+	assert.Equal(t, 0, rat.Cmp(expected))
+
+	bigFloat, err := amount.BigFloat()
+	assert.NoError(t, err)
+	assert.Equal(t, "1234567890.123456789012345678", bigFloat.Text('f', 18))
+
+	_, err = amount.BigInt()
+	assert.Equal(t, jsonparser.ERROR_INVALID_INTEGER, err)
+
+	exponent, err := jsonparser.GetNumber(financialJson, "exponent")
+	assert.NoError(t, err)
+	exponentInt, err := exponent.BigInt()
+	assert.NoError(t, err)
+	assert.Equal(t, "1000000000000000000000000000000", exponentInt.String())
+
+	fraction, err := jsonparser.GetNumber(financialJson, "fraction")
+	assert.NoError(t, err)
+	fractionFloat, err := fraction.Float64()
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0025, fractionFloat)
+}
+
+func TestGet_BigNumbers(t *testing.T) {
+	var bigInt big.Int
+	_, err := jsonparser.Get(&bigInt, financialJson, "id")
+	assert.NoError(t, err)
+	assert.Equal(t, "123456789012345678901234567890", bigInt.String())
+
+	var bigIntPtr *big.Int
+	_, err = jsonparser.Get(&bigIntPtr, financialJson, "negative_id")
+	assert.NoError(t, err)
+	assert.Equal(t, "-98765432109876543210", bigIntPtr.String())
+
+	var bigFloat *big.Float
+	_, err = jsonparser.Get(&bigFloat, financialJson, "amount")
+	assert.NoError(t, err)
+	assert.Equal(t, "1234567890.123456789012345678", bigFloat.Text('f', 18))
+
+	var rat big.Rat
+	_, err = jsonparser.Get(&rat, financialJson, "fraction")
+	assert.NoError(t, err)
+	assert.Equal(t, "1/400", rat.String())
+
+	var number json.Number
+	_, err = jsonparser.Get(&number, financialJson, "amount")
+	assert.NoError(t, err)
+	assert.Equal(t, json.Number("1234567890.123456789012345678"), number)
+
+	_, err = jsonparser.Get(&number, financialJson, "label")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	optional, err := jsonparser.GetOptional[*big.Int](financialJson, "id")
+	assert.NoError(t, err)
+	assert.True(t, optional.Valid())
+	assert.Equal(t, "123456789012345678901234567890", optional.Value.String())
+
+	or, err := jsonparser.GetOr(financialJson, jsonparser.Number("0"), "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, jsonparser.Number("0"), or)
+}
@@ -0,0 +1,111 @@
+package jsonparser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+// payload is "\xfb\xff\xfe binary?" so every alphabet has its own characters
+var attachmentJson = []byte(`{
+	"std": "+//+IGJpbmFyeT8=",
+	"stdRaw": "+//+IGJpbmFyeT8",
+	"url": "-__-IGJpbmFyeT8=",
+	"urlRaw": "-__-IGJpbmFyeT8",
+	"escaped": "+\/\/+IGJpbmFyeT8=",
+	"hex": "fbfffe2062696e6172793f",
+	"empty": "",
+	"mixed": "+/-_",
+	"broken": "not base64!",
+	"nothing": null,
+	"size": 11
+}`)
+
+var attachmentPayload = []byte("\xfb\xff\xfe binary?")
+
+func TestGetBytes(t *testing.T) {
+	for _, field := range []string{"std", "stdRaw", "url", "urlRaw", "escaped"} {
+		result, err := jsonparser.GetBytes(attachmentJson, field)
+		assert.NoError(t, err, "Error getting %s", field)
+		assert.Equal(t, attachmentPayload, result, "%s should decode to the payload", field)
+	}
+
+	result, err := jsonparser.GetBytes(attachmentJson, "empty")
+	assert.NoError(t, err)
+	assert.Empty(t, result)
+
+	result, err = jsonparser.GetBytes(attachmentJson, "nothing")
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+
+	_, err = jsonparser.GetBytes(attachmentJson, "mixed")
+	assert.Equal(t, jsonparser.ERROR_INVALID_STRING, err)
+
+	_, err = jsonparser.GetBytes(attachmentJson, "broken")
+	assert.Equal(t, jsonparser.ERROR_INVALID_STRING, err)
+
+	_, err = jsonparser.GetBytes(attachmentJson, "size")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	_, err = jsonparser.GetBytes(attachmentJson, "missing")
+	assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, err)
+}
+
+func TestAppendBytes(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	buf = append(buf, "prefix:"...)
+
+	result, err := jsonparser.AppendBytes(buf, attachmentJson, "url")
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte("prefix:"), attachmentPayload...), result)
+
+	// the caller buffer is reused when large enough
+	assert.Equal(t, &buf[:1][0], &result[:1][0])
+
+	// on errors dst is returned unchanged
+	result, err = jsonparser.AppendBytes(buf, attachmentJson, "broken")
+	assert.Error(t, err)
+	assert.Equal(t, []byte("prefix:"), result)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = jsonparser.AppendBytes(buf[:0], attachmentJson, "std")
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func TestGetHex(t *testing.T) {
+	result, err := jsonparser.GetHex(attachmentJson, "hex")
+	assert.NoError(t, err)
+	assert.Equal(t, attachmentPayload, result)
+
+	result, err = jsonparser.AppendHex([]byte{0x00}, attachmentJson, "hex")
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte{0x00}, attachmentPayload...), result)
+
+	_, err = jsonparser.GetHex(attachmentJson, "std")
+	assert.Equal(t, jsonparser.ERROR_INVALID_STRING, err)
+}
+
+func TestGet_BytesTarget(t *testing.T) {
+	// like encoding/json, []byte is padded standard base64
+	var data []byte
+	_, err := jsonparser.Get(&data, attachmentJson, "std")
+	assert.NoError(t, err)
+	assert.Equal(t, attachmentPayload, data)
+
+	_, err = jsonparser.Get(&data, attachmentJson, "urlRaw")
+	assert.Equal(t, jsonparser.ERROR_INVALID_STRING, err)
+
+	_, err = jsonparser.Get(&data, attachmentJson, "nothing")
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	optional, err := jsonparser.GetOptional[[]byte](attachmentJson, "escaped")
+	assert.NoError(t, err)
+	assert.Equal(t, attachmentPayload, optional.Value)
+
+	_, err = jsonparser.GetOptional[[]byte](attachmentJson, "size")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+}
@@ -0,0 +1,156 @@
+package jsonparser_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var looselyTypedJson = []byte(`{
+	"age": "30",
+	"padded": " 42 ",
+	"score": "85.5",
+	"count": 7,
+	"active": "yes",
+	"enabled": 1,
+	"disabled": "OFF",
+	"verified": "true",
+	"deleted": false,
+	"name": "John",
+	"nothing": null
+}`)
+
+func TestCoercion_Default(t *testing.T) {
+	var o jsonparser.Options
+
+	age, err := o.GetInt(looselyTypedJson, "age")
+	assert.NoError(t, err)
+	assert.Equal(t, 30, age)
+
+	_, err = o.GetBool(looselyTypedJson, "active")
+	assert.Error(t, err, "yes is not a boolean by default")
+
+	_, err = o.GetInt(looselyTypedJson, "padded")
+	assert.Error(t, err)
+}
+
+func TestCoercion_Loose(t *testing.T) {
+	o := jsonparser.Options{Coercion: jsonparser.COERCE_LOOSE}
+
+	tests := []struct {
+		field    string
+		expected bool
+	}{
+		{"active", true},
+		{"enabled", true},
+		{"disabled", false},
+		{"verified", true},
+		{"deleted", false},
+	}
+
+	for _, tt := range tests {
+		result, err := o.GetBool(looselyTypedJson, tt.field)
+		assert.NoError(t, err, "Error getting %s", tt.field)
+		assert.Equal(t, tt.expected, result, "%s should equal %t", tt.field, tt.expected)
+	}
+
+	_, err := o.GetBool(looselyTypedJson, "name")
+	assert.Equal(t, jsonparser.ERROR_INVALID_BOOLEAN, err)
+
+	padded, err := o.GetInt(looselyTypedJson, "padded")
+	assert.NoError(t, err)
+	assert.Equal(t, 42, padded)
+
+	score, err := o.GetFloat64(looselyTypedJson, "score")
+	assert.NoError(t, err)
+	assert.Equal(t, 85.5, score)
+
+	count, err := o.GetString(looselyTypedJson, "count")
+	assert.NoError(t, err)
+	assert.Equal(t, "7", count)
+
+	var active bool
+	_, err = jsonparser.GetWith(o, &active, looselyTypedJson, "active")
+	assert.NoError(t, err)
+	assert.True(t, active)
+
+	var age int64
+	_, err = jsonparser.GetWith(o, &age, looselyTypedJson, "padded")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), age)
+}
+
+func TestCoercion_Strict(t *testing.T) {
+	o := jsonparser.Options{Coercion: jsonparser.COERCE_STRICT}
+
+	_, err := o.GetInt(looselyTypedJson, "age")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	_, err = o.GetBool(looselyTypedJson, "verified")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	_, err = o.GetString(looselyTypedJson, "count")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	_, err = o.GetString(looselyTypedJson, "nothing")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	count, err := o.GetInt64(looselyTypedJson, "count")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), count)
+
+	var age int
+	_, err = jsonparser.GetWith(o, &age, looselyTypedJson, "age")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	var name sql.NullString
+	_, err = jsonparser.GetWith(o, &name, looselyTypedJson, "nothing")
+	assert.NoError(t, err)
+	assert.False(t, name.Valid)
+
+	var nullAge sql.NullInt64
+	_, err = jsonparser.GetWith(o, &nullAge, looselyTypedJson, "age")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	_, err = jsonparser.GetWith(o, &nullAge, []byte(`{"age": " 30 "}`), "age")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	_, err = jsonparser.GetWith(o, &nullAge, looselyTypedJson, "count")
+	assert.NoError(t, err)
+	assert.Equal(t, sql.NullInt64{Int64: 7, Valid: true}, nullAge)
+
+	var nullBool sql.NullBool
+	_, err = jsonparser.GetWith(o, &nullBool, looselyTypedJson, "verified")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	var nullCount sql.Null[int32]
+	_, err = jsonparser.GetWith(o, &nullCount, looselyTypedJson, "age")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	_, err = jsonparser.GetWith(o, &name, looselyTypedJson, "count")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	_, err = jsonparser.GetWith(o, &name, looselyTypedJson, "name")
+	assert.NoError(t, err)
+	assert.Equal(t, sql.NullString{String: "John", Valid: true}, name)
+}
+
+func TestOptions_ParseBoolAndInt(t *testing.T) {
+	loose := jsonparser.Options{Coercion: jsonparser.COERCE_LOOSE}
+
+	for _, spelling := range []string{"true", "TRUE", "1", "yes", "Yes", "on"} {
+		result, err := loose.ParseBool([]byte(spelling))
+		assert.NoError(t, err, spelling)
+		assert.True(t, result, spelling)
+	}
+
+	_, err := jsonparser.Options{}.ParseBool([]byte("yes"))
+	assert.Equal(t, jsonparser.ERROR_INVALID_BOOLEAN, err)
+
+	result, err := loose.ParseInt([]byte(" 12\t"))
+	assert.NoError(t, err)
+	assert.Equal(t, 12, result)
+}
@@ -0,0 +1,96 @@
+package jsonparser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+func TestCursor_Navigation(t *testing.T) {
+	c, err := jsonparser.NewCursor(profileJson)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, c.Depth())
+	assert.Equal(t, jsonparser.TYPE_OBJECT, c.Value().Kind())
+
+	assert.NoError(t, c.Down("profile"))
+	assert.Equal(t, "profile", c.Key())
+	assert.Equal(t, -1, c.Index())
+
+	assert.NoError(t, c.Down("zip"))
+	assert.Equal(t, 2, c.Depth())
+	zip, err := c.Value().Int()
+	assert.NoError(t, err)
+	assert.Equal(t, 10001, zip)
+
+	// the offset points into the original buffer
+	assert.Equal(t, "10001", string(profileJson[c.Offset():c.Offset()+5]))
+
+	assert.NoError(t, c.Up())
+	assert.Equal(t, "profile", c.Key())
+	assert.NoError(t, c.Up())
+	assert.Equal(t, 0, c.Offset())
+	assert.Equal(t, jsonparser.ERROR_ARGUMENTS, c.Up())
+
+	assert.NoError(t, c.Down("tags"))
+	assert.NoError(t, c.DownIndex(2))
+	assert.Equal(t, 2, c.Index())
+	assert.NoError(t, c.Down("lang"))
+	assert.Equal(t, "go", c.Value().String())
+}
+
+func TestCursor_Errors(t *testing.T) {
+	c, _ := jsonparser.NewCursor(profileJson)
+
+	assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, c.Down("missing"))
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, c.DownIndex(0))
+	assert.Equal(t, 0, c.Depth(), "a failed move leaves the cursor in place")
+
+	assert.NoError(t, c.Down("tags"))
+	assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, c.DownIndex(3))
+	assert.Equal(t, jsonparser.ERROR_ARGUMENTS, c.DownIndex(-1))
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, c.Down("lang"))
+
+	assert.NoError(t, c.DownIndex(0))
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, c.DownFirst())
+
+	empty, _ := jsonparser.NewCursor([]byte(`{"list": []}`))
+	assert.NoError(t, empty.Down("list"))
+	assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, empty.DownFirst())
+
+	_, err := jsonparser.NewCursor([]byte("  "))
+	assert.Equal(t, jsonparser.ERROR_INVALID_JSON, err)
+}
+
+func TestCursor_Siblings(t *testing.T) {
+	c, _ := jsonparser.NewCursor(profileJson)
+	assert.False(t, c.Next(), "the root has no siblings")
+
+	assert.NoError(t, c.DownFirst())
+	var keys []string
+	for {
+		keys = append(keys, c.Key())
+		if !c.Next() {
+			break
+		}
+	}
+	assert.NoError(t, c.Err())
+	assert.Equal(t, []string{"name", "age", "active", "score", "tags", "profile"}, keys)
+
+	// scan the tags until the first object
+	assert.NoError(t, c.Up())
+	assert.NoError(t, c.Down("tags"))
+	assert.NoError(t, c.DownFirst())
+	for c.Value().Kind() != jsonparser.TYPE_OBJECT && c.Next() {
+	}
+	assert.Equal(t, 2, c.Index())
+	assert.False(t, c.Next())
+	assert.Equal(t, 2, c.Index(), "the cursor stays on the last element")
+
+	broken, _ := jsonparser.NewCursor([]byte(`[1, 2 3]`))
+	broken.DownFirst()
+	assert.True(t, broken.Next())
+	assert.False(t, broken.Next())
+	assert.Equal(t, jsonparser.ERROR_INVALID_JSON, broken.Err())
+}
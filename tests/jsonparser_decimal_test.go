@@ -0,0 +1,167 @@
+package jsonparser_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var invoiceJson = []byte(`{
+	"currency": "EUR",
+	"lines": [
+		{"price": 19.99, "quantity": 3},
+		{"price": 0.1, "quantity": 1},
+		{"price": 0.20, "quantity": 1}
+	],
+	"total": 60.27,
+	"discount": -5.5,
+	"big": 1e3,
+	"tooPrecise": 12345678901234567890.5
+}`)
+
+func TestParseDecimal(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		mantissa int64
+		scale    int
+	}{
+		{"19.99", "19.99", 1999, 2},
+		{"0.20", "0.20", 20, 2},
+		{"-5.5", "-5.5", -55, 1},
+		{"0.05", "0.05", 5, 2},
+		{"-0.001", "-0.001", -1, 3},
+		{"42", "42", 42, 0},
+		{"0", "0", 0, 0},
+		{"1e3", "1000", 1000, 0},
+		{"2.5e-3", "0.0025", 25, 4},
+		{"1.5E2", "150", 150, 0},
+		{"-9223372036854775808", "-9223372036854775808", math.MinInt64, 0},
+		{"0.000", "0.000", 0, 3},
+		{"1.50e1", "15.0", 150, 1},
+		{"1.0e5", "100000", 100000, 0},
+		{"1.00000000000000000000", "1", 1, 0},
+		{"12.3400000000000000000", "12.34", 1234, 2},
+		{"0.10000000000000000000", "0.1", 1, 1},
+		{"-7.000000000000000000", "-7.000000000000000000", -7000000000000000000, 18},
+	}
+
+	for _, tt := range tests {
+		result, err := jsonparser.ParseDecimal([]byte(tt.input))
+		assert.NoError(t, err, "Error parsing %s", tt.input)
+		assert.Equal(t, tt.expected, result.String(), "%s should format as %s", tt.input, tt.expected)
+		assert.Equal(t, tt.mantissa, result.Mantissa(), "%s mantissa", tt.input)
+		assert.Equal(t, tt.scale, result.Scale(), "%s scale", tt.input)
+	}
+
+	_, err := jsonparser.ParseDecimal([]byte("12345678901234567890.5"))
+	assert.Equal(t, jsonparser.ERROR_INTEGER_OVERFLOW, err)
+
+	_, err = jsonparser.ParseDecimal([]byte("1.2.3"))
+	assert.Equal(t, jsonparser.ERROR_INVALID_FLOAT, err)
+}
+
+// Test the sum of the invoice lines has no rounding error
+func TestDecimal_Sum(t *testing.T) {
+	total := jsonparser.NewDecimal(0, 0)
+
+	err := jsonparser.Foreach(invoiceJson, func(line []byte, index int) {
+		price, err := jsonparser.GetDecimal(line, "price")
+		assert.NoError(t, err)
+		quantity, err := jsonparser.GetDecimal(line, "quantity")
+		assert.NoError(t, err)
+
+		amount, err := price.Mul(quantity)
+		assert.NoError(t, err)
+		total, err = total.Add(amount)
+		assert.NoError(t, err)
+	}, "lines")
+	assert.NoError(t, err)
+
+	expected, err := jsonparser.GetDecimal(invoiceJson, "total")
+	assert.NoError(t, err)
+	assert.True(t, total.Equal(expected), "%s should equal %s", total, expected)
+	assert.Equal(t, "60.27", total.String())
+
+	// 0.1 + 0.2 is not 0.3 with floats
+	tenth, _ := jsonparser.ParseDecimal([]byte("0.1"))
+	fifth, _ := jsonparser.ParseDecimal([]byte("0.2"))
+	sum, err := tenth.Add(fifth)
+	assert.NoError(t, err)
+	assert.Equal(t, "0.3", sum.String())
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a := jsonparser.NewDecimal(1999, 2)
+	b := jsonparser.NewDecimal(-55, 1)
+
+	sum, err := a.Add(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "14.49", sum.String())
+
+	diff, err := a.Sub(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "25.49", diff.String())
+
+	product, err := a.Mul(b)
+	assert.NoError(t, err)
+	assert.Equal(t, "-109.945", product.String())
+	assert.Equal(t, "-109.95", product.Round(2).String())
+	assert.Equal(t, "-110", product.Round(0).String())
+	assert.Equal(t, "0.1", jsonparser.NewDecimal(149, 3).Round(1).String())
+
+	assert.Equal(t, 1, a.Cmp(b))
+	assert.Equal(t, -1, b.Cmp(a))
+	assert.True(t, jsonparser.NewDecimal(20, 2).Equal(jsonparser.NewDecimal(2, 1)))
+	assert.Equal(t, 1, jsonparser.NewDecimal(1, -30).Cmp(jsonparser.NewDecimal(math.MaxInt64, 0)))
+
+	_, err = jsonparser.NewDecimal(math.MaxInt64, 0).Add(jsonparser.NewDecimal(1, 0))
+	assert.Equal(t, jsonparser.ERROR_INTEGER_OVERFLOW, err)
+
+	_, err = jsonparser.NewDecimal(math.MaxInt64, 0).Mul(jsonparser.NewDecimal(2, 0))
+	assert.Equal(t, jsonparser.ERROR_INTEGER_OVERFLOW, err)
+
+	_, err = jsonparser.NewDecimal(1, 0).Add(jsonparser.NewDecimal(1, 19))
+	assert.Equal(t, jsonparser.ERROR_INTEGER_OVERFLOW, err)
+
+	assert.Equal(t, 19.99, a.Float64())
+}
+
+func TestDecimal_JSON(t *testing.T) {
+	var line struct {
+		Price    jsonparser.Decimal `json:"price"`
+		Quantity jsonparser.Decimal `json:"quantity"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"price": 0.20, "quantity": "3"}`), &line))
+	assert.Equal(t, "0.20", line.Price.String())
+	assert.Equal(t, "3", line.Quantity.String())
+
+	out, err := json.Marshal(line)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"price":0.20,"quantity":3}`, string(out))
+}
+
+func TestGet_Decimal(t *testing.T) {
+	var discount jsonparser.Decimal
+	_, err := jsonparser.Get(&discount, invoiceJson, "discount")
+	assert.NoError(t, err)
+	assert.Equal(t, jsonparser.NewDecimal(-55, 1), discount)
+
+	big, err := jsonparser.GetDecimal(invoiceJson, "big")
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", big.String())
+
+	_, err = jsonparser.GetDecimal(invoiceJson, "tooPrecise")
+	assert.Equal(t, jsonparser.ERROR_INTEGER_OVERFLOW, err)
+
+	_, err = jsonparser.GetDecimal(invoiceJson, "currency")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	optional, err := jsonparser.GetOptional[jsonparser.Decimal](invoiceJson, "lines", "2", "price")
+	assert.NoError(t, err)
+	assert.Equal(t, "0.20", optional.Value.String())
+}
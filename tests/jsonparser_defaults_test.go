@@ -0,0 +1,130 @@
+package jsonparser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+func TestGetStringOr(t *testing.T) {
+	result, err := jsonparser.GetStringOr(primitivesTestJson, "default", "stringValue")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World!", result)
+
+	result, err = jsonparser.GetStringOr(primitivesTestJson, "default", "emptyString")
+	assert.NoError(t, err)
+	assert.Equal(t, "", result, "empty string is present and should not fall back")
+
+	result, err = jsonparser.GetStringOr(primitivesTestJson, "default", "nonExistent")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", result)
+
+	result, err = jsonparser.GetStringOr(primitivesTestJson, "default", "nullValue")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", result)
+
+	result, err = jsonparser.GetStringOr(primitivesTestJson, "default", "intPositive")
+	assert.NoError(t, err, "any scalar is read as a string like GetString does")
+	assert.Equal(t, "42", result)
+}
+
+func TestGetIntOr(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   []string
+		expected int
+	}{
+		{"present", []string{"intNegative"}, -123},
+		{"nested", []string{"nested", "deepInt"}, 999},
+		{"missing", []string{"nonExistent"}, 7},
+		{"missing nested", []string{"nested", "nonExistent"}, 7},
+		{"null", []string{"nullValue"}, 7},
+		{"null element", []string{"mixedArray", "4"}, 7},
+		{"array out of bounds", []string{"arrayOfInts", "100"}, 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := jsonparser.GetIntOr(primitivesTestJson, 7, tt.fields...)
+			assert.NoError(t, err, "Error getting %v", tt.fields)
+			assert.Equal(t, tt.expected, result, "%v should equal %d", tt.fields, tt.expected)
+		})
+	}
+
+	_, err := jsonparser.GetIntOr(primitivesTestJson, 7, "stringValue")
+	_, getErr := jsonparser.GetInt(primitivesTestJson, "stringValue")
+	assert.Error(t, err)
+	assert.Equal(t, getErr, err, "the error should be the one of GetInt")
+
+	result, err := jsonparser.GetIntOr([]byte(`{"a": "5"}`), 7, "a")
+	assert.NoError(t, err, "quoted numbers are read like GetInt does")
+	assert.Equal(t, 5, result)
+
+	result, err = jsonparser.GetIntOr([]byte(`{"a": 5}`), 7, "a", "0")
+	assert.NoError(t, err, "an index into a scalar is a missing path")
+	assert.Equal(t, 7, result)
+
+	result, err = jsonparser.GetIntOr([]byte(`{"a": 5}`), 7, "a", "b")
+	assert.NoError(t, err, "a key into a scalar is a missing path")
+	assert.Equal(t, 7, result)
+
+	_, err = jsonparser.GetIntOr(primitivesTestJson, 7, "floatPositive")
+	assert.Error(t, err, "a float is not an int")
+
+	_, err = jsonparser.GetIntOr([]byte(`{"a": nul}`), 7, "a")
+	assert.Error(t, err, "malformed null should not fall back")
+}
+
+func TestGetBoolOr(t *testing.T) {
+	result, err := jsonparser.GetBoolOr(primitivesTestJson, true, "boolFalse")
+	assert.NoError(t, err)
+	assert.False(t, result)
+
+	result, err = jsonparser.GetBoolOr(primitivesTestJson, true, "nonExistent")
+	assert.NoError(t, err)
+	assert.True(t, result)
+
+	_, err = jsonparser.GetBoolOr(primitivesTestJson, true, "intZero")
+	assert.Equal(t, jsonparser.ERROR_INVALID_BOOLEAN, err)
+
+	result, err = jsonparser.GetBoolOr([]byte(`{"a": "false"}`), true, "a")
+	assert.NoError(t, err, "quoted booleans are read like GetBool does")
+	assert.False(t, result)
+}
+
+func TestGetFloat64Or(t *testing.T) {
+	result, err := jsonparser.GetFloat64Or(primitivesTestJson, 1.5, "floatScientific")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.23e-4, result)
+
+	result, err = jsonparser.GetFloat64Or(primitivesTestJson, 1.5, "nullValue")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, result)
+
+	result, err = jsonparser.GetFloat64Or([]byte(`{"a": "2.5"}`), 1.5, "a")
+	assert.NoError(t, err, "quoted numbers are read like GetFloat64 does")
+	assert.Equal(t, 2.5, result)
+
+	result, err = jsonparser.GetFloat64Or([]byte(`{"a": 5}`), 1.5, "a", "0")
+	assert.NoError(t, err, "an index into a scalar is a missing path")
+	assert.Equal(t, 1.5, result)
+}
+
+func TestGetOr(t *testing.T) {
+	name, err := jsonparser.GetOr(primitivesTestJson, "none", "nested", "deepString")
+	assert.NoError(t, err)
+	assert.Equal(t, "Nested string value", name)
+
+	count, err := jsonparser.GetOr[int64](primitivesTestJson, 5, "nonExistent")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+
+	_, err = jsonparser.GetOr(primitivesTestJson, false, "stringValue")
+	assert.Equal(t, jsonparser.ERROR_INVALID_BOOLEAN, err)
+
+	count, err = jsonparser.GetOr[int64]([]byte(`{"a": "3"}`), 5, "a")
+	assert.NoError(t, err, "quoted numbers are decoded like Get does")
+	assert.Equal(t, int64(3), count)
+}
@@ -0,0 +1,92 @@
+package jsonparser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+func TestDelete(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		fields   []string
+		expected string
+	}{
+		{"first member", `{"a": 1, "b": 2, "c": 3}`, []string{"a"}, `{"b": 2, "c": 3}`},
+		{"middle member", `{"a": 1, "b": 2, "c": 3}`, []string{"b"}, `{"a": 1, "c": 3}`},
+		{"last member", `{"a": 1, "b": 2, "c": 3}`, []string{"c"}, `{"a": 1, "b": 2}`},
+		{"only member", `{ "a": 1 }`, []string{"a"}, `{}`},
+		{"first element", `[1,2,3]`, []string{"0"}, `[2,3]`},
+		{"middle element", `[1,2,3]`, []string{"1"}, `[1,3]`},
+		{"last element", `[1,2,3]`, []string{"2"}, `[1,2]`},
+		{"only element", `[[1]]`, []string{"0", "0"}, `[[]]`},
+		{"container", `{"a": {"b": [1, {"c": 2}]}, "d": 3}`, []string{"a"}, `{"d": 3}`},
+		{"nested", `{"a": {"b": [1, {"c": 2}]}, "d": 3}`, []string{"a", "b", "1", "c"}, `{"a": {"b": [1, {}]}, "d": 3}`},
+		{"key in string", `{"x": "\"a\": 1", "a": 2}`, []string{"a"}, `{"x": "\"a\": 1"}`},
+		{
+			"indented", "{\n  \"id\": 7,\n  \"internal\": {\"trace\": \"x\"},\n  \"name\": \"n\"\n}", []string{"internal"},
+			"{\n  \"id\": 7,\n  \"name\": \"n\"\n}",
+		},
+		{
+			"indented last", "{\n  \"id\": 7,\n  \"internal\": true\n}", []string{"internal"},
+			"{\n  \"id\": 7\n}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := jsonparser.Delete([]byte(tt.json), tt.fields...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(res))
+			assert.True(t, json.Valid(res))
+		})
+	}
+}
+
+func TestDelete_EscapedKeys(t *testing.T) {
+	res, err := jsonparser.Delete([]byte(`{"x\ny": {"\"": 1, "a\\b": 2}}`), "x\ny", `"`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"x\ny": {"a\\b": 2}}`, string(res))
+
+	res, err = jsonparser.Delete(res, "x\ny", `a\b`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"x\ny": {}}`, string(res))
+}
+
+func TestDelete_NumericKeys(t *testing.T) {
+	res, err := jsonparser.Delete([]byte(`{"1": "a", "2": [0, 1]}`), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"2": [0, 1]}`, string(res))
+
+	res, err = jsonparser.Delete(res, "2", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"2": [0]}`, string(res))
+}
+
+func TestDelete_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   string
+		fields []string
+		err    error
+	}{
+		{"no fields", `{"a": 1}`, nil, jsonparser.ERROR_ARGUMENTS},
+		{"missing key", `{"a": 1}`, []string{"b"}, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"missing parent", `{"a": 1}`, []string{"b", "c"}, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"index out of range", `[1]`, []string{"1"}, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"key in array", `[1]`, []string{"a"}, jsonparser.ERROR_TYPE_MISMATCH},
+		{"missing numeric key", `{"a": 1}`, []string{"0"}, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"through a scalar", `{"a": 1}`, []string{"a", "b"}, jsonparser.ERROR_TYPE_MISMATCH},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jsonparser.Delete([]byte(tt.json), tt.fields...)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
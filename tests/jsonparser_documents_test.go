@@ -0,0 +1,104 @@
+package jsonparser_test
+
+import (
+	"runtime"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+// scanDocuments returns every document of the stream, the skipped count and the error
+func scanDocuments(input string) ([]string, int, error) {
+	s := jsonparser.NewDocumentScanner(iotest.HalfReader(strings.NewReader(input)))
+
+	var docs []string
+	for s.Next() {
+		docs = append(docs, string(s.Document()))
+	}
+
+	return docs, s.Skipped(), s.Err()
+}
+
+func TestDocumentScanner_Concatenated(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"back to back", `{"a":1}{"b":[2]}[3]`, []string{`{"a":1}`, `{"b":[2]}`, `[3]`}},
+		{"whitespace", " {\"a\": \"}{\"}\n\n\t{\"b\": 2} ", []string{`{"a": "}{"}`, `{"b": 2}`}},
+		{"scalars", `"x" 1 2.5 true null`, []string{`"x"`, `1`, `2.5`, `true`, `null`}},
+		{"empty", "  \n ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			docs, skipped, err := scanDocuments(tt.input)
+			assert.NoError(t, err)
+			assert.Equal(t, 0, skipped)
+			assert.Equal(t, tt.expected, docs)
+		})
+	}
+
+	docs, _, err := scanDocuments(`{"a":1}{"b":`)
+	assert.Equal(t, []string{`{"a":1}`}, docs)
+	assert.Error(t, err, "a corrupted value without a following RS stops the scanner")
+}
+
+func TestDocumentScanner_TextSequence(t *testing.T) {
+	docs, skipped, err := scanDocuments("\x1e{\"a\":1}\n\x1e[1,2]\n\x1e\"text\"\n\x1e42\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, skipped)
+	assert.Equal(t, []string{`{"a":1}`, `[1,2]`, `"text"`, `42`}, docs)
+
+	// corrupted and truncated records are dropped, the scanner resyncs at the next RS
+	docs, skipped, err = scanDocuments("\x1e{\"a\":1}\n" +
+		"\x1e{\"broken\": [1, 2\n" +
+		"\x1e{\"b\":2} garbage\n" +
+		"\x1e\x1e\n" +
+		"\x1e{\"c\":3}\n" +
+		"\x1e123") // a number without the final newline may be truncated
+	assert.NoError(t, err)
+	assert.Equal(t, 3, skipped)
+	assert.Equal(t, []string{`{"a":1}`, `{"c":3}`}, docs)
+
+	// a corrupted concatenated value is skipped when a RS follows it
+	docs, skipped, err = scanDocuments("{\"a\":1}{\"bad\": [\x1e{\"c\":3}\n")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, []string{`{"a":1}`, `{"c":3}`}, docs)
+}
+
+func TestDocumentScanner_Large(t *testing.T) {
+	large := `{"data": "` + strings.Repeat("x", 150*1024) + `"}`
+
+	docs, _, err := scanDocuments(large + large + "\x1e" + large + "\n")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{large, large, large}, docs)
+}
+
+func TestDocumentScanner_CorruptedLarge(t *testing.T) {
+	// the corrupted value fails at once, the data up to the RS is dropped as it is read
+	input := "trux" + strings.Repeat("p", 8*1024*1024) + "\x1e{\"b\": 1}\n"
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	s := jsonparser.NewDocumentScanner(strings.NewReader(input))
+	var docs []string
+	for s.Next() {
+		docs = append(docs, string(s.Document()))
+	}
+
+	runtime.ReadMemStats(&after)
+
+	assert.NoError(t, s.Err())
+	assert.Equal(t, 1, s.Skipped())
+	assert.Equal(t, []string{`{"b": 1}`}, docs)
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(4*1024*1024), "the corrupted value should not be buffered")
+}
@@ -0,0 +1,242 @@
+package jsonparser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var payloadJson = `{
+  "id": 42,
+  "user": {"name": "ada", "password": "secret", "role": "admin"},
+  "items": [1, 2, 3],
+  "debug": {"trace": "abc"}
+}`
+
+func TestEditor(t *testing.T) {
+	e := jsonparser.NewEditor()
+	e.Delete("user", "password")
+	e.Delete("debug")
+	e.Rename("username", "user", "name")
+	e.Set([]byte(`"user"`), "user", "role")
+	e.Set([]byte(`4`), "items", "3")
+	e.Delete("items", "0")
+	e.Set([]byte(`{"source": "api"}`), "meta")
+
+	res, err := e.Apply(nil, []byte(payloadJson))
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "id": 42,
+  "user": {"username": "ada", "role": "user"},
+  "items": [2, 3, 4],
+  "meta": {"source": "api"}
+}`, string(res))
+	assert.True(t, json.Valid(res))
+}
+
+func TestEditor_Buffer(t *testing.T) {
+	e := jsonparser.NewEditor()
+	e.Set([]byte(`2`), "a")
+
+	buf := make([]byte, 0, 64)
+	buf = append(buf, "out: "...)
+	res, err := e.Apply(buf, []byte(`{"a":1}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `out: {"a":2}`, string(res))
+	assert.Equal(t, &buf[:1][0], &res[0], "the caller buffer is reused")
+
+	e.Reset()
+	res, err = e.Apply(nil, []byte(`{"a":1}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, string(res), "no operations copy the document")
+}
+
+func TestEditor_Deletes(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   [][]string
+		expected string
+	}{
+		{"adjacent", [][]string{{"1"}, {"2"}}, `[0, 3, 4]`},
+		{"first", [][]string{{"0"}, {"1"}}, `[2, 3, 4]`},
+		{"last", [][]string{{"3"}, {"4"}}, `[0, 1, 2]`},
+		{"around", [][]string{{"0"}, {"2"}, {"4"}}, `[1, 3]`},
+		{"all", [][]string{{"0"}, {"1"}, {"2"}, {"3"}, {"4"}}, `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := jsonparser.NewEditor()
+			for _, fields := range tt.fields {
+				e.Delete(fields...)
+			}
+
+			res, err := e.Apply(nil, []byte(`[0, 1, 2, 3, 4]`))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(res))
+		})
+	}
+
+	e := jsonparser.NewEditor()
+	e.Delete("a")
+	e.Set([]byte(`2`), "b")
+	res, err := e.Apply(nil, []byte(`{"a": 1}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b": 2}`, string(res), "a member created in an emptied object")
+}
+
+func TestEditor_EscapedKeys(t *testing.T) {
+	e := jsonparser.NewEditor()
+	e.Set([]byte(`1`), `a"b\c`)
+	e.Set([]byte(`2`), `q"`, "0")
+	e.Delete(`x"y`)
+
+	res, err := e.Apply(nil, []byte(`{"x\"y": 0}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a\"b\\c": 1, "q\"": [2]}`, string(res))
+	assert.True(t, json.Valid(res))
+
+	// the new key of a rename is escaped and compared escaped with the other keys
+	e = jsonparser.NewEditor()
+	e.Rename(`q"\`, "a")
+	res, err = e.Apply(nil, []byte(`{"a": 1, "b": 2}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"q\"\\": 1, "b": 2}`, string(res))
+	assert.True(t, json.Valid(res))
+
+	e.Reset()
+	e.Rename(`q"`, "a")
+	_, err = e.Apply(nil, []byte(`{"a": 1, "q\"": 2}`))
+	assert.Equal(t, jsonparser.ERROR_EDIT_CONFLICT, err)
+}
+
+func TestEditor_NumericKeys(t *testing.T) {
+	// a numeric field is a key in an object and an index in an array, as for Set, Delete and the getters
+	doc := []byte(`{"1": "a", "2": "b", "list": ["x", "y"], "ids": {}}`)
+
+	e := jsonparser.NewEditor()
+	e.Set([]byte(`"A"`), "1")
+	e.Delete("2")
+	e.Set([]byte(`"Y"`), "list", "1")
+	e.Set([]byte(`7`), "ids", "10")
+	e.Set([]byte(`true`), "new", "0")
+
+	res, err := e.Apply(nil, doc)
+	assert.NoError(t, err)
+	expected := `{"1": "A", "list": ["x", "Y"], "ids": {"10":7}, "new": [true]}`
+	assert.Equal(t, expected, string(res))
+
+	// the same edits made one at a time
+	res = doc
+	for _, edit := range []func([]byte) ([]byte, error){
+		func(json []byte) ([]byte, error) { return jsonparser.Set(json, []byte(`"A"`), "1") },
+		func(json []byte) ([]byte, error) { return jsonparser.Delete(json, "2") },
+		func(json []byte) ([]byte, error) { return jsonparser.Set(json, []byte(`"Y"`), "list", "1") },
+		func(json []byte) ([]byte, error) { return jsonparser.Set(json, []byte(`7`), "ids", "10") },
+		func(json []byte) ([]byte, error) { return jsonparser.Set(json, []byte(`true`), "new", "0") },
+	} {
+		res, err = edit(res)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, expected, string(res))
+
+	value, err := jsonparser.GetInt(res, "ids", "10")
+	assert.NoError(t, err)
+	assert.Equal(t, 7, value)
+
+	e = jsonparser.NewEditor()
+	e.Set([]byte(`1`), "list", "key")
+	_, err = e.Apply(nil, doc)
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err, "a key in an array")
+}
+
+func TestEditor_CreatedSiblings(t *testing.T) {
+	e := jsonparser.NewEditor()
+	e.Set([]byte(`1`), "x", "y")
+	e.Set([]byte(`2`), "x", "z")
+
+	res, err := e.Apply(nil, []byte(`{}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"x":{"y":1,"z":2}}`, string(res))
+
+	// the members are built together at every depth, in the order of the operations
+	e = jsonparser.NewEditor()
+	e.Set([]byte(`1`), "new", "a", "b")
+	e.Set([]byte(`2`), "id")
+	e.Set([]byte(`3`), "new", "c")
+	e.Set([]byte(`4`), "new", "a", "d", "0")
+	e.Set([]byte(`5`), "user", "extra", "e")
+	e.Set([]byte(`6`), "user", "extra", "f")
+
+	res, err = e.Apply(nil, []byte(payloadJson))
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "id": 2,
+  "user": {"name": "ada", "password": "secret", "role": "admin", "extra": {"e": 5, "f": 6}},
+  "items": [1, 2, 3],
+  "debug": {"trace": "abc"},
+  "new": {"a": {"b": 1, "d": [4]}, "c": 3}
+}`, string(res))
+	assert.True(t, json.Valid(res))
+}
+
+func TestEditor_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(e *jsonparser.Editor)
+		err  error
+	}{
+		{"same path", func(e *jsonparser.Editor) {
+			e.Set([]byte(`1`), "id")
+			e.Delete("id")
+		}, jsonparser.ERROR_EDIT_CONFLICT},
+		{"nested paths", func(e *jsonparser.Editor) {
+			e.Delete("user")
+			e.Set([]byte(`1`), "user", "name")
+		}, jsonparser.ERROR_EDIT_CONFLICT},
+		{"rename to an existing key", func(e *jsonparser.Editor) {
+			e.Rename("role", "user", "name")
+		}, jsonparser.ERROR_EDIT_CONFLICT},
+		{"rename to a created key", func(e *jsonparser.Editor) {
+			e.Rename("new", "id")
+			e.Set([]byte(`1`), "new")
+		}, jsonparser.ERROR_EDIT_CONFLICT},
+		{"key in a created array", func(e *jsonparser.Editor) {
+			e.Set([]byte(`1`), "new", "0")
+			e.Set([]byte(`2`), "new", "a")
+		}, jsonparser.ERROR_TYPE_MISMATCH},
+		{"missing delete", func(e *jsonparser.Editor) {
+			e.Delete("missing")
+		}, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"index past the end", func(e *jsonparser.Editor) {
+			e.Set([]byte(`1`), "items", "4")
+		}, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"rename an element", func(e *jsonparser.Editor) {
+			e.Rename("x", "items", "0")
+		}, jsonparser.ERROR_TYPE_MISMATCH},
+		{"through a scalar", func(e *jsonparser.Editor) {
+			e.Set([]byte(`1`), "id", "a")
+		}, jsonparser.ERROR_TYPE_MISMATCH},
+		{"invalid value", func(e *jsonparser.Editor) {
+			e.Set([]byte(`{`), "id")
+		}, jsonparser.ERROR_INVALID_JSON},
+		{"delete the document", func(e *jsonparser.Editor) {
+			e.Delete()
+		}, jsonparser.ERROR_ARGUMENTS},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := jsonparser.NewEditor()
+			tt.edit(e)
+
+			dst := []byte("kept")
+			res, err := e.Apply(dst, []byte(payloadJson))
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, "kept", string(res))
+		})
+	}
+}
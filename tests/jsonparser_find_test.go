@@ -0,0 +1,93 @@
+package jsonparser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var webhookJson = []byte(`{
+  "request_id": "top",
+  "event": {
+    "type": "order",
+    "meta": {"request_id": "meta-1", "tags": ["a", "b"]},
+    "items": [
+      {"sku": "x", "request_id": "item-0"},
+      {"sku": "y", "nested": [{"request_id": 42}]}
+    ]
+  }
+}`)
+
+func pathString(path []jsonparser.PathSegment) []string {
+	res := make([]string, len(path))
+	for i, segment := range path {
+		res[i] = segment.String()
+	}
+	return res
+}
+
+func TestFindAll(t *testing.T) {
+	paths := [][]string{}
+	values := []string{}
+
+	err := jsonparser.FindAll(webhookJson, "request_id", func(path []jsonparser.PathSegment, value []byte) error {
+		paths = append(paths, pathString(path))
+		values = append(values, string(value))
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"request_id"},
+		{"event", "meta", "request_id"},
+		{"event", "items", "0", "request_id"},
+		{"event", "items", "1", "nested", "0", "request_id"},
+	}, paths)
+	assert.Equal(t, []string{"top", "meta-1", "item-0", "42"}, values)
+}
+
+// Test the reported paths can be used with the getters
+func TestFindAll_PathRoundTrip(t *testing.T) {
+	err := jsonparser.FindAll(webhookJson, "request_id", func(path []jsonparser.PathSegment, value []byte) error {
+		result, err := jsonparser.GetString(webhookJson, pathString(path)...)
+		assert.NoError(t, err)
+		assert.Equal(t, string(value), result)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestFindAll_CallbackError(t *testing.T) {
+	calls := 0
+	err := jsonparser.FindAll(webhookJson, "request_id", func(path []jsonparser.PathSegment, value []byte) error {
+		calls++
+		return jsonparser.ERROR_ARGUMENTS
+	})
+
+	assert.Equal(t, jsonparser.ERROR_ARGUMENTS, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestFindFirst(t *testing.T) {
+	path, value, err := jsonparser.FindFirst(webhookJson, "sku")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"event", "items", "0", "sku"}, pathString(path))
+	assert.Equal(t, "x", string(value))
+
+	_, _, err = jsonparser.FindFirst(webhookJson, "missing")
+	assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, err)
+
+	_, _, err = jsonparser.FindFirst([]byte(`{"a": [1, 2`), "a")
+	assert.Error(t, err)
+}
+
+func BenchmarkFindAll(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = jsonparser.FindAll(webhookJson, "request_id", func(path []jsonparser.PathSegment, value []byte) error {
+			return nil
+		})
+	}
+}
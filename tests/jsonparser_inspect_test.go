@@ -0,0 +1,114 @@
+package jsonparser_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+func TestExists(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   []string
+		expected bool
+	}{
+		{"top level", []string{"stringValue"}, true},
+		{"null value", []string{"nullValue"}, true},
+		{"nested", []string{"nested", "level2", "veryDeepInt"}, true},
+		{"array element", []string{"arrayOfInts", "7"}, true},
+		{"missing", []string{"nonExistent"}, false},
+		{"missing nested", []string{"nested", "stringValue"}, false},
+		{"sibling of parent", []string{"nested", "level2", "deepInt"}, false},
+		{"inside a scalar", []string{"stringValue", "boolTrue"}, false},
+		{"index of a scalar", []string{"intNegative", "0"}, false},
+		{"index of a string", []string{"stringValue", "0"}, false},
+		{"index of an object", []string{"nested", "0"}, false},
+		{"array out of bounds", []string{"arrayOfInts", "8"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, jsonparser.Exists(primitivesTestJson, tt.fields...), "Exists(%v)", tt.fields)
+		})
+	}
+
+	assert.False(t, jsonparser.Exists(arrayTestJson, "empty_array", "0"))
+	assert.True(t, jsonparser.Exists([]byte(`["a\\", 5]`), "1"))
+}
+
+func TestType(t *testing.T) {
+	tests := []struct {
+		fields   []string
+		expected jsonparser.ValueType
+	}{
+		{[]string{}, jsonparser.TYPE_OBJECT},
+		{[]string{"stringValue"}, jsonparser.TYPE_STRING},
+		{[]string{"intNegative"}, jsonparser.TYPE_NUMBER},
+		{[]string{"boolFalse"}, jsonparser.TYPE_BOOLEAN},
+		{[]string{"nullValue"}, jsonparser.TYPE_NULL},
+		{[]string{"nested"}, jsonparser.TYPE_OBJECT},
+		{[]string{"arrayOfFloats"}, jsonparser.TYPE_ARRAY},
+	}
+
+	for _, tt := range tests {
+		result, err := jsonparser.Type(primitivesTestJson, tt.fields...)
+		assert.NoError(t, err, "Error getting type of %v", tt.fields)
+		assert.Equal(t, tt.expected, result, "type of %v should be %s", tt.fields, tt.expected)
+	}
+
+	_, err := jsonparser.Type(primitivesTestJson, "nonExistent")
+	assert.Error(t, err)
+}
+
+func TestLen(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     []byte
+		fields   []string
+		expected int
+	}{
+		{"array", primitivesTestJson, []string{"arrayOfInts"}, 8},
+		{"mixed array", primitivesTestJson, []string{"mixedArray"}, 6},
+		{"object", primitivesTestJson, []string{"nested", "level2", "level3"}, 4},
+		{"string", primitivesTestJson, []string{"stringValue"}, 13},
+		{"empty string", primitivesTestJson, []string{"emptyString"}, 0},
+		{"empty array", arrayTestJson, []string{"empty_array"}, 0},
+		{"array of objects", arrayTestJson, []string{"array_of_objects"}, 3},
+		{"nested arrays", arrayTestJson, []string{"nested_arrays", "3"}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := jsonparser.Len(tt.json, tt.fields...)
+			assert.NoError(t, err, "Error getting length of %v", tt.fields)
+			assert.Equal(t, tt.expected, result, "length of %v should be %d", tt.fields, tt.expected)
+		})
+	}
+
+	_, err := jsonparser.Len(primitivesTestJson, "intZero")
+	assert.Equal(t, jsonparser.ERROR_ARGUMENTS, err)
+}
+
+func TestKeys(t *testing.T) {
+	keys := slices.Collect(jsonparser.Keys(primitivesTestJson, "nested", "level2", "level3"))
+	assert.Equal(t, []string{"extremelyDeepString", "extremelyDeepBool", "extremelyDeepInt", "extremelyDeepFloat"}, keys)
+
+	keys = slices.Collect(jsonparser.Keys(arrayTestJson, "array_of_objects", "1"))
+	assert.Equal(t, []string{"id", "name", "email", "active"}, keys)
+
+	// early break
+	count := 0
+	for range jsonparser.Keys(primitivesTestJson) {
+		count++
+		if count == 2 {
+			break
+		}
+	}
+	assert.Equal(t, 2, count)
+
+	assert.Empty(t, slices.Collect(jsonparser.Keys(primitivesTestJson, "arrayOfInts")))
+	assert.Empty(t, slices.Collect(jsonparser.Keys(primitivesTestJson, "nonExistent")))
+}
@@ -0,0 +1,111 @@
+package jsonparser_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var logLines = "{\"level\": \"info\", \"msg\": \"started\", \"ms\": 1}\r\n" +
+	"\n" +
+	"   \n" +
+	"{\"level\": \"warn\", \"msg\": \"slow\", \"ms\": 250}\n" +
+	"{\"level\": \"info\", \"msg\": \"done\", \"ms\": 3}"
+
+func TestLinesReader(t *testing.T) {
+	r := jsonparser.NewLinesReader(strings.NewReader(logLines))
+
+	var messages []string
+	var lines []int
+	for r.Next() {
+		msg, err := jsonparser.GetString(r.Record(), "msg")
+		assert.NoError(t, err)
+		messages = append(messages, msg)
+		lines = append(lines, r.Line())
+	}
+
+	assert.NoError(t, r.Err())
+	assert.Equal(t, []string{"started", "slow", "done"}, messages)
+	assert.Equal(t, []int{1, 4, 5}, lines)
+}
+
+func TestLinesReader_Value(t *testing.T) {
+	r := jsonparser.NewLinesReader(strings.NewReader(logLines))
+
+	total := 0
+	for r.Next() {
+		ms, err := r.Value().Get("ms")
+		assert.NoError(t, err)
+
+		n, err := ms.Int()
+		assert.NoError(t, err)
+		total += n
+	}
+
+	assert.Equal(t, 254, total)
+}
+
+func TestLinesReader_Errors(t *testing.T) {
+	r := jsonparser.NewLinesReader(strings.NewReader("{\"a\": 1}\n\n{\"a\": 2} trailing\n{\"a\": 3}\n"))
+
+	assert.True(t, r.Next())
+	assert.False(t, r.Next())
+
+	var lineErr *jsonparser.LineError
+	assert.True(t, errors.As(r.Err(), &lineErr))
+	assert.Equal(t, 3, lineErr.Line)
+	assert.ErrorIs(t, r.Err(), jsonparser.ERROR_INVALID_JSON)
+	assert.Equal(t, "line 3: invalid JSON", r.Err().Error())
+	assert.False(t, r.Next(), "the reader stays stopped")
+
+	// errors of the callback carry the line of the record
+	r = jsonparser.NewLinesReader(strings.NewReader(logLines))
+	err := r.Each(func(record []byte) error {
+		_, err := jsonparser.GetInt(record, "missing")
+		return err
+	})
+	assert.True(t, errors.As(err, &lineErr))
+	assert.Equal(t, 1, lineErr.Line)
+	assert.ErrorIs(t, err, jsonparser.ERROR_FIELD_NOT_FOUND)
+}
+
+func TestLinesReader_LongLine(t *testing.T) {
+	long := `{"data": "` + strings.Repeat("x", 256*1024) + `"}`
+	r := jsonparser.NewLinesReader(strings.NewReader(long + "\n{}\n"))
+
+	count := 0
+	assert.NoError(t, r.Each(func(record []byte) error {
+		count++
+		return nil
+	}))
+	assert.Equal(t, 2, count)
+}
+
+func TestLinesWriter(t *testing.T) {
+	var out bytes.Buffer
+	w := jsonparser.NewLinesWriter(&out)
+
+	assert.NoError(t, w.Write([]byte("{\n  \"msg\": \"a b\",\n  \"tags\": [ 1, 2, { } ],\n  \"ok\" : true\n}")))
+	assert.NoError(t, w.Write([]byte(` "text with \" quote" `)))
+	assert.NoError(t, w.Write([]byte(`42`)))
+
+	assert.Equal(t, "{\"msg\":\"a b\",\"tags\":[1,2,{}],\"ok\":true}\n\"text with \\\" quote\"\n42\n", out.String())
+
+	assert.Error(t, w.Write([]byte(`{"a": }`)))
+	assert.Equal(t, jsonparser.ERROR_INVALID_STRING, w.Write([]byte("\"new\nline\"")))
+	assert.Equal(t, 3, strings.Count(out.String(), "\n"), "invalid records are not written")
+
+	// what the writer produces the reader reads back
+	r := jsonparser.NewLinesReader(&out)
+	count := 0
+	for r.Next() {
+		count++
+	}
+	assert.NoError(t, r.Err())
+	assert.Equal(t, 3, count)
+}
@@ -0,0 +1,121 @@
+package jsonparser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+// examples from RFC 7386, appendix A
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		target   string
+		patch    string
+		expected string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		res, err := jsonparser.MergePatch([]byte(tt.target), []byte(tt.patch))
+		assert.NoError(t, err, tt.patch)
+		assert.Equal(t, tt.expected, string(res), tt.patch)
+	}
+}
+
+func TestMergePatch_KeepsOrder(t *testing.T) {
+	target := `{
+  "title": "Goodbye!",
+  "author": {
+    "givenName": "John",
+    "familyName": "Doe"
+  },
+  "tags": ["example", "sample"],
+  "content": "This will be unchanged"
+}`
+	patch := `{
+  "title": "Hello!",
+  "phoneNumber": "+01-123-456-7890",
+  "author": {"familyName": null},
+  "tags": ["example"]
+}`
+
+	res, err := jsonparser.MergePatch([]byte(target), []byte(patch))
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "title": "Hello!",
+  "author": {
+    "givenName": "John"
+  },
+  "tags": ["example"],
+  "content": "This will be unchanged",
+  "phoneNumber": "+01-123-456-7890"
+}`, string(res))
+
+	res, err = jsonparser.MergePatch([]byte(`{"1": "a", "2": "b"}`), []byte(`{"2": null, "3": "c"}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"1": "a", "3": "c"}`, string(res), "numeric keys are keys")
+
+	res, err = jsonparser.MergePatch([]byte(`{"a\"b": 1}`), []byte(`{"a\"b": 2, "c\\d": {"e\"": 3}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a\"b": 2, "c\\d": {"e\"":3}}`, string(res), "escaped keys are kept")
+}
+
+func TestCreateMergePatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		modified string
+		expected string
+	}{
+		{"unchanged", `{"a": 1, "b": [1, 2]}`, `{"b": [1, 2], "a": 1.0}`, `{}`},
+		{"changed", `{"a": 1, "b": "x"}`, `{"a": 2, "b": "x"}`, `{"a":2}`},
+		{"removed and added", `{"a": 1, "b": 2}`, `{"b": 2, "c": {"d": true}}`, `{"a":null,"c":{"d": true}}`},
+		{"nested", `{"a": {"b": 1, "c": 2}, "d": 3}`, `{"a": {"b": 1, "c": 3, "e": 4}, "d": 3}`, `{"a":{"c":3,"e":4}}`},
+		{"array", `{"a": [1, 2]}`, `{"a": [1]}`, `{"a":[1]}`},
+		{"not an object", `{"a": 1}`, `[1]`, `[1]`},
+		{"large integer", `{"id": 9007199254740992}`, `{"id": 9007199254740993}`, `{"id":9007199254740993}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			patch, err := jsonparser.CreateMergePatch([]byte(tt.original), []byte(tt.modified))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(patch))
+			assert.True(t, json.Valid(patch))
+
+			// applying the patch gives back modified
+			res, err := jsonparser.MergePatch([]byte(tt.original), patch)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.modified, string(res))
+		})
+	}
+}
+
+func TestMergePatch_Errors(t *testing.T) {
+	_, err := jsonparser.MergePatch([]byte(`{"a": 1}`), []byte(`{"a": `))
+	assert.Equal(t, jsonparser.ERROR_INVALID_JSON, err)
+
+	_, err = jsonparser.MergePatch([]byte(``), []byte(`{"a": 1}`))
+	assert.Equal(t, jsonparser.ERROR_INVALID_JSON, err)
+
+	_, err = jsonparser.CreateMergePatch([]byte(`{"a": 1}`), []byte(`{"a"`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package jsonparser_test
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+func TestParseInt64(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int64
+	}{
+		{"0", 0},
+		{"-0", 0},
+		{"+7", 7},
+		{"42", 42},
+		{"-123", -123},
+		{"007", 7},
+		{"9223372036854775807", math.MaxInt64},
+		{"-9223372036854775808", math.MinInt64},
+	}
+
+	for _, tt := range tests {
+		result, err := jsonparser.ParseInt64([]byte(tt.input))
+		assert.NoError(t, err, "Error parsing %s", tt.input)
+		assert.Equal(t, tt.expected, result, "%s should equal %d", tt.input, tt.expected)
+	}
+}
+
+func TestParseInt64_Errors(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected error
+	}{
+		{"", jsonparser.ERROR_INVALID_INTEGER},
+		{"-", jsonparser.ERROR_INVALID_INTEGER},
+		{"1.5", jsonparser.ERROR_INVALID_INTEGER},
+		{"12a", jsonparser.ERROR_INVALID_INTEGER},
+		{"1e3", jsonparser.ERROR_INVALID_INTEGER},
+		{"9223372036854775808", jsonparser.ERROR_INTEGER_OVERFLOW},
+		{"-9223372036854775809", jsonparser.ERROR_INTEGER_OVERFLOW},
+		{"99999999999999999999999", jsonparser.ERROR_INTEGER_OVERFLOW},
+	}
+
+	for _, tt := range tests {
+		_, err := jsonparser.ParseInt64([]byte(tt.input))
+		assert.Equal(t, tt.expected, err, "parsing %q", tt.input)
+	}
+
+	_, err := jsonparser.ParseInt([]byte("9223372036854775808"))
+	assert.Equal(t, jsonparser.ERROR_INTEGER_OVERFLOW, err)
+}
+
+func TestParseInt_Limit(t *testing.T) {
+	// the bounds of int depend on the platform, 2^31-1 on 32-bit ones
+	result, err := jsonparser.ParseInt([]byte(strconv.Itoa(math.MaxInt)))
+	assert.NoError(t, err)
+	assert.Equal(t, math.MaxInt, result)
+
+	result, err = jsonparser.ParseInt([]byte(strconv.Itoa(math.MinInt)))
+	assert.NoError(t, err)
+	assert.Equal(t, math.MinInt, result)
+
+	_, err = jsonparser.ParseInt([]byte(strconv.FormatUint(uint64(math.MaxInt)+1, 10)))
+	assert.Equal(t, jsonparser.ERROR_INTEGER_OVERFLOW, err)
+
+	_, err = jsonparser.ParseInt([]byte("-" + strconv.FormatUint(uint64(math.MaxInt)+2, 10)))
+	assert.Equal(t, jsonparser.ERROR_INTEGER_OVERFLOW, err)
+
+	if strconv.IntSize == 32 {
+		_, err = jsonparser.ParseInt([]byte("3000000000"))
+		assert.Equal(t, jsonparser.ERROR_INTEGER_OVERFLOW, err)
+	}
+}
+
+func TestParseFloat64(t *testing.T) {
+	inputs := []string{
+		"0", "-0", "0.0", "1", "-1", "3.14159", "-2.71828", "1.23e-4", "1E10", "2.5e+3",
+		"0.1", "0.3", "19.99", "123456789012345678", "9007199254740993", "1e22", "1e23",
+		"1.7976931348623157e+308", "2.2250738585072014e-308", "4.9e-324", "0.000000000000000000000000001",
+		"123456789.123456789", "1.00000000000000000000001",
+	}
+
+	for _, input := range inputs {
+		expected, _ := strconv.ParseFloat(input, 64)
+		result, err := jsonparser.ParseFloat64([]byte(input))
+		assert.NoError(t, err, "Error parsing %s", input)
+		assert.Equal(t, math.Float64bits(expected), math.Float64bits(result), "%s should equal %v, got %v", input, expected, result)
+	}
+
+	for _, input := range []string{"", "-", ".", "1e", "1.2.3", "abc", "1e999"} {
+		_, err := jsonparser.ParseFloat64([]byte(input))
+		assert.Equal(t, jsonparser.ERROR_INVALID_FLOAT, err, "parsing %q", input)
+	}
+}
+
+// Test the fast paths agree with strconv on random decimal numbers
+func TestParseFloat_Random(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 20000; i++ {
+		mantissa := r.Int63n(1 << uint(r.Intn(62)+1))
+		exp := r.Intn(60) - 30
+		input := strconv.FormatInt(mantissa, 10) + "e" + strconv.Itoa(exp)
+		if i%2 == 0 {
+			input = strconv.FormatFloat(float64(mantissa)/math.Pow10(r.Intn(10)), 'f', -1, 64)
+		}
+
+		expected64, _ := strconv.ParseFloat(input, 64)
+		result64, err := jsonparser.ParseFloat64([]byte(input))
+		assert.NoError(t, err)
+		assert.Equal(t, expected64, result64, "parsing %s as float64", input)
+
+		expected32, err := strconv.ParseFloat(input, 32)
+		if err != nil {
+			continue // out of float32 range
+		}
+		result32, err := jsonparser.ParseFloat32([]byte(input))
+		assert.NoError(t, err)
+		assert.Equal(t, float32(expected32), result32, "parsing %s as float32", input)
+	}
+}
+
+func TestParseNumbers_ZeroAllocations(t *testing.T) {
+	integer := []byte("-9223372036854775808")
+	float := []byte("1.7976931348623157e+308")
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_, _ = jsonparser.ParseInt64(integer)
+		_, _ = jsonparser.ParseInt(integer)
+		_, _ = jsonparser.ParseFloat64(float)
+		_, _ = jsonparser.ParseFloat32(float[:4])
+		_, _ = jsonparser.GetInt64(primitivesTestJson, "int64Minimum")
+		_, _ = jsonparser.GetFloat64(primitivesTestJson, "floatLarge")
+	})
+	assert.Equal(t, 0.0, allocs)
+}
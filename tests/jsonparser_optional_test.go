@@ -0,0 +1,72 @@
+package jsonparser_test
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+func TestGetOptional(t *testing.T) {
+	present, err := jsonparser.GetOptional[int](primitivesTestJson, "intPositive")
+	assert.NoError(t, err)
+	assert.Equal(t, jsonparser.Optional[int]{Value: 42, Present: true}, present)
+	assert.True(t, present.Valid())
+
+	null, err := jsonparser.GetOptional[int](primitivesTestJson, "nullValue")
+	assert.NoError(t, err)
+	assert.Equal(t, jsonparser.Optional[int]{Present: true, Null: true}, null)
+	assert.False(t, null.Valid())
+	assert.Equal(t, 3, null.Or(3))
+
+	missing, err := jsonparser.GetOptional[int](primitivesTestJson, "nonExistent")
+	assert.NoError(t, err)
+	assert.Equal(t, jsonparser.Optional[int]{}, missing)
+
+	str, err := jsonparser.GetOptional[string](arrayTestJson, "mixed_array", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", str.Or("default"))
+
+	_, err = jsonparser.GetOptional[string](primitivesTestJson, "intPositive")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+}
+
+func TestGet_SqlNullTypes(t *testing.T) {
+	var nullString sql.NullString
+	_, err := jsonparser.Get(&nullString, primitivesTestJson, "stringValue")
+	assert.NoError(t, err)
+	assert.Equal(t, sql.NullString{String: "Hello, World!", Valid: true}, nullString)
+
+	_, err = jsonparser.Get(&nullString, primitivesTestJson, "nullValue")
+	assert.NoError(t, err)
+	assert.Equal(t, sql.NullString{}, nullString)
+
+	var nullInt sql.NullInt64
+	_, err = jsonparser.Get(&nullInt, primitivesTestJson, "int64Minimum")
+	assert.NoError(t, err)
+	assert.Equal(t, sql.NullInt64{Int64: -9223372036854775808, Valid: true}, nullInt)
+
+	_, err = jsonparser.Get(&nullInt, arrayTestJson, "null_array", "1")
+	assert.NoError(t, err)
+	assert.False(t, nullInt.Valid)
+
+	var nullFloat sql.NullFloat64
+	_, err = jsonparser.Get(&nullFloat, primitivesTestJson, "floatNegative")
+	assert.NoError(t, err)
+	assert.Equal(t, sql.NullFloat64{Float64: -2.71828, Valid: true}, nullFloat)
+
+	var nullBool sql.NullBool
+	_, err = jsonparser.Get(&nullBool, primitivesTestJson, "boolTrue")
+	assert.NoError(t, err)
+	assert.Equal(t, sql.NullBool{Bool: true, Valid: true}, nullBool)
+
+	var generic sql.Null[string]
+	_, err = jsonparser.Get(&generic, primitivesTestJson, "nested", "deepString")
+	assert.NoError(t, err)
+	assert.Equal(t, sql.Null[string]{V: "Nested string value", Valid: true}, generic)
+
+	_, err = jsonparser.Get(&nullInt, primitivesTestJson, "nested")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+}
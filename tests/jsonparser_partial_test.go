@@ -0,0 +1,127 @@
+package jsonparser_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+// truncatedPaths formats the truncated paths of p as dotted strings
+func truncatedPaths(p jsonparser.Partial) []string {
+	var paths []string
+	for _, path := range p.Truncated {
+		paths = append(paths, strings.Join(pathString(path), "."))
+	}
+	return paths
+}
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		expected  string
+		truncated []string
+	}{
+		{"complete", `{"a": [1, 2]}`, `{"a": [1, 2]}`, nil},
+		{"open string", `{"answer": "The capital of France is Par`, `{"answer": "The capital of France is Par"}`, []string{"", "answer"}},
+		{"open array", `{"items": [1, 2`, `{"items": [1, 2]}`, []string{"", "items", "items.1"}},
+		{"string at the end", `["a", "b"`, `["a", "b"]`, []string{""}},
+		{"after comma", `{"a": 1, "b": [true, `, `{"a": 1, "b": [true]}`, []string{"", "b"}},
+		{"open key", `{"a": 1, "na`, `{"a": 1}`, []string{""}},
+		{"missing colon", `{"a": 1, "name"`, `{"a": 1}`, []string{""}},
+		{"missing value", `{"a": 1, "name": `, `{"a": 1}`, []string{""}},
+		{"literal", `[{"ok": tr`, `[{"ok": true}]`, []string{"", "0", "0.ok"}},
+		{"number", `{"pi": 3.`, `{"pi": 3}`, []string{"", "pi"}},
+		{"exponent", `[1, 2e-`, `[1, 2]`, []string{"", "1"}},
+		{"lone minus", `[1, -`, `[1]`, []string{""}},
+		{"cut escape", `["a\`, `["a"]`, []string{"", "0"}},
+		{"cut unicode escape", `["a\u00`, `["a"]`, []string{"", "0"}},
+		{"nested", `{"a": {"b": [{"c": "x"}, {"d": `, `{"a": {"b": [{"c": "x"}, {}]}}`, []string{"", "a", "a.b", "a.b.1"}},
+		{"root string", `"hello`, `"hello"`, []string{""}},
+		{"empty object", `{`, `{}`, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := jsonparser.Recover([]byte(tt.input))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(p.JSON))
+			assert.True(t, json.Valid(p.JSON))
+			assert.Equal(t, tt.truncated, truncatedPaths(p))
+		})
+	}
+}
+
+func TestRecover_Fields(t *testing.T) {
+	p, err := jsonparser.Recover([]byte(`{"id": "call-1", "tool": "search", "args": {"query": "go json", "limit": 1`))
+	assert.NoError(t, err)
+
+	// complete fields are read as usual from the recovered document
+	tool, err := jsonparser.GetString(p.JSON, "tool")
+	assert.NoError(t, err)
+	assert.Equal(t, "search", tool)
+	assert.False(t, p.IsTruncated("tool"))
+	assert.False(t, p.IsTruncated("args", "query"))
+
+	assert.True(t, p.IsTruncated("args"))
+	assert.True(t, p.IsTruncated("args", "limit"), "a number at the end may have lost digits")
+	assert.True(t, p.IsTruncated())
+}
+
+func TestRecover_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{"empty", ``, jsonparser.ERROR_INVALID_JSON},
+		{"whitespace", `  `, jsonparser.ERROR_INVALID_JSON},
+		{"invalid before the end", `{"a" 1, "b": 2`, jsonparser.ERROR_COLON_NOT_FOUND},
+		{"mismatched", `[1}`, jsonparser.ERROR_INVALID_JSON},
+		{"bad literal", `[trux`, jsonparser.ERROR_INVALID_BOOLEAN},
+		{"bad value", `{"a": x`, jsonparser.ERROR_INVALID_JSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jsonparser.Recover([]byte(tt.input))
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestGetPartial(t *testing.T) {
+	doc := []byte(`{"status": "ok", "data": {"rows": [[1, 2], [3, 4`)
+
+	value, truncated, err := jsonparser.GetPartial(doc, "status")
+	assert.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Equal(t, `"ok"`, string(value))
+
+	value, truncated, err = jsonparser.GetPartial(doc, "data", "rows", "0")
+	assert.NoError(t, err)
+	assert.False(t, truncated)
+	assert.Equal(t, `[1, 2]`, string(value))
+
+	value, truncated, err = jsonparser.GetPartial(doc, "data", "rows")
+	assert.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Equal(t, `[[1, 2], [3, 4]]`, string(value))
+
+	value, truncated, err = jsonparser.GetPartial(doc, "data")
+	assert.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Equal(t, `{"rows": [[1, 2], [3, 4]]}`, string(value))
+
+	value, truncated, err = jsonparser.GetPartial([]byte(`{"text": "unfinished sent`), "text")
+	assert.NoError(t, err)
+	assert.True(t, truncated)
+	assert.Equal(t, `"unfinished sent"`, string(value))
+
+	_, _, err = jsonparser.GetPartial([]byte(`{"a": 1, "b": `), "b")
+	assert.Error(t, err, "the value was cut before it started")
+}
@@ -0,0 +1,156 @@
+package jsonparser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+func TestParsePointer(t *testing.T) {
+	tests := []struct {
+		pointer  string
+		expected []string
+	}{
+		{"", nil},
+		{"/", []string{""}},
+		{"/a/0", []string{"a", "0"}},
+		{"/a~1b/m~0n", []string{"a/b", "m~n"}},
+		{"/~01", []string{"~1"}},
+	}
+
+	for _, tt := range tests {
+		tokens, err := jsonparser.ParsePointer(tt.pointer)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, tokens, tt.pointer)
+	}
+
+	for _, pointer := range []string{"a", "/a~", "/a~2"} {
+		_, err := jsonparser.ParsePointer(pointer)
+		assert.Equal(t, jsonparser.ERROR_INVALID_POINTER, err, pointer)
+	}
+}
+
+// examples from RFC 6902, appendix A
+func TestApplyPatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{"add member", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"foo": "bar", "baz": "qux"}`},
+		{"add element", `{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`},
+		{"append", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc", "def"]}]`, `{"foo": ["bar", ["abc", "def"]]}`},
+		{"remove member", `{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`},
+		{"remove element", `{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`},
+		{"replace", `{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`},
+		{
+			"move member", `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`,
+			`[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			`{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`,
+		},
+		{"move element", `{"foo": ["all", "grass", "cows", "eat"]}`, `[{"op": "move", "from": "/foo/1", "path": "/foo/3"}]`, `{"foo": ["all", "cows", "eat", "grass"]}`},
+		{"copy", `{"a": {"b": 1}}`, `[{"op": "copy", "from": "/a", "path": "/c"}]`, `{"a": {"b": 1}, "c": {"b": 1}}`},
+		{"test", `{"baz": "qux", "foo": ["a", 2, "c"]}`, `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2}]`, `{"baz": "qux", "foo": ["a", 2, "c"]}`},
+		{"escaped key", `{"/": 9, "~1": 10}`, `[{"op": "replace", "path": "/~01", "value": 11}, {"op": "remove", "path": "/~1"}]`, `{"~1": 11}`},
+		{"escaped characters", `{"a\"b": 1}`, `[{"op": "replace", "path": "/a\"b", "value": 2}, {"op": "add", "path": "/c\\d", "value": 3}]`, `{"a\"b": 2, "c\\d": 3}`},
+		{"whole document", `{"a": 1}`, `[{"op": "replace", "path": "", "value": [1]}]`, `[1]`},
+		{"numeric key", `{"1": "x"}`, `[{"op": "replace", "path": "/1", "value": "y"}]`, `{"1": "y"}`},
+		{"add numeric key", `{"a": {}}`, `[{"op": "add", "path": "/a/10", "value": 1}, {"op": "add", "path": "/a/-", "value": 2}]`, `{"a": {"10":1,"-":2}}`},
+		{"leading zero key", `{"a": {"01": 1}}`, `[{"op": "move", "from": "/a/01", "path": "/a/00"}]`, `{"a": {"00":1}}`},
+		{"sequence", `{"list": []}`, `[{"op": "add", "path": "/list/0", "value": 1}, {"op": "add", "path": "/list/0", "value": 0}, {"op": "test", "path": "/list", "value": [0, 1]}]`, `{"list": [0, 1]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := jsonparser.ApplyPatch([]byte(tt.doc), []byte(tt.patch))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(res))
+			assert.True(t, json.Valid(res))
+		})
+	}
+}
+
+func TestApplyPatch_KeepsFormatting(t *testing.T) {
+	doc := "{\n  \"name\": \"svc\",\n  \"replicas\": 2,\n  \"ports\": [\n    80\n  ]\n}"
+	patch := `[
+		{"op": "replace", "path": "/replicas", "value": 3},
+		{"op": "add", "path": "/ports/0", "value": 443}
+	]`
+
+	res, err := jsonparser.ApplyPatch([]byte(doc), []byte(patch))
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"name\": \"svc\",\n  \"replicas\": 3,\n  \"ports\": [\n    443,\n    80\n  ]\n}", string(res))
+}
+
+func TestApplyPatch_Test(t *testing.T) {
+	doc := `{"obj": {"a": 1, "b": [true, null]}, "s": "é", "n": 100, "id": 9007199254740993, "f": 0.1}`
+
+	tests := []struct {
+		value string
+		path  string
+		equal bool
+	}{
+		{`{"b": [true, null], "a": 1.0}`, "/obj", true},
+		{`{"a": 1}`, "/obj", false},
+		{`[true]`, "/obj/b", false},
+		{`"é"`, "/s", true},
+		{`1e2`, "/n", true},
+		{`"100"`, "/n", false},
+		{`9007199254740993`, "/id", true},
+		{`9007199254740992`, "/id", false},
+		{`9.007199254740993e15`, "/id", true},
+		{`0.10`, "/f", true},
+		{`0.1000000000000000055511151231257827`, "/f", false},
+	}
+
+	for _, tt := range tests {
+		patch := `[{"op": "test", "path": "` + tt.path + `", "value": ` + tt.value + `}]`
+		_, err := jsonparser.ApplyPatch([]byte(doc), []byte(patch))
+		if tt.equal {
+			assert.NoError(t, err, tt.value)
+		} else {
+			assert.Equal(t, jsonparser.ERROR_TEST_FAILED, err, tt.value)
+		}
+	}
+}
+
+func TestApplyPatch_Errors(t *testing.T) {
+	doc := `{"foo": "bar", "list": [1, 2]}`
+
+	tests := []struct {
+		name  string
+		patch string
+		err   error
+	}{
+		{"not an array", `{"op": "remove", "path": "/foo"}`, jsonparser.ERROR_INVALID_PATCH},
+		{"unknown op", `[{"op": "merge", "path": "/foo"}]`, jsonparser.ERROR_INVALID_PATCH},
+		{"missing path", `[{"op": "remove"}]`, jsonparser.ERROR_INVALID_PATCH},
+		{"missing value", `[{"op": "add", "path": "/x"}]`, jsonparser.ERROR_INVALID_PATCH},
+		{"missing from", `[{"op": "copy", "path": "/x"}]`, jsonparser.ERROR_INVALID_PATCH},
+		{"invalid pointer", `[{"op": "remove", "path": "foo"}]`, jsonparser.ERROR_INVALID_POINTER},
+		{"missing parent", `[{"op": "add", "path": "/a/b", "value": 1}]`, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"replace missing", `[{"op": "replace", "path": "/x", "value": 1}]`, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"index out of range", `[{"op": "add", "path": "/list/3", "value": 1}]`, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"leading zero index", `[{"op": "replace", "path": "/list/01", "value": 1}]`, jsonparser.ERROR_INVALID_POINTER},
+		{"leading zero add", `[{"op": "add", "path": "/list/00", "value": 1}]`, jsonparser.ERROR_INVALID_POINTER},
+		{"leading zero from", `[{"op": "copy", "from": "/list/01", "path": "/x"}]`, jsonparser.ERROR_INVALID_POINTER},
+		{"move into itself", `[{"op": "move", "from": "/list", "path": "/list/0"}]`, jsonparser.ERROR_INVALID_PATCH},
+		{"failed test", `[{"op": "test", "path": "/foo", "value": "baz"}]`, jsonparser.ERROR_TEST_FAILED},
+		{
+			"atomic", `[{"op": "remove", "path": "/foo"}, {"op": "add", "path": "/list/-", "value": 3}, {"op": "remove", "path": "/missing"}]`,
+			jsonparser.ERROR_FIELD_NOT_FOUND,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := jsonparser.ApplyPatch([]byte(doc), []byte(tt.patch))
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, doc, string(res), "the original is returned on failure")
+		})
+	}
+}
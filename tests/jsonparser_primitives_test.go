@@ -154,3 +154,70 @@ func TestGetString_ErrorCases(t *testing.T) {
 		})
 	}
 }
+
+func TestForeach_Containers(t *testing.T) {
+	json := []byte(`{"list": [ {"a": 1}, {"b": {"c": "}"}} , [3] ]}`)
+
+	var values []string
+	err := jsonparser.Foreach(json, func(value []byte, index int) {
+		values = append(values, string(value))
+	}, "list")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`{"a": 1}`, `{"b": {"c": "}"}}`, `[3]`}, values)
+}
+
+func TestGet_StopsAtContainerEnd(t *testing.T) {
+	json := []byte(`{"a": {"b": 1}, "c": 2, "list": [[1], 2], "s": "x"}`)
+
+	tests := []struct {
+		name   string
+		fields []string
+	}{
+		{"key of the parent", []string{"a", "c"}},
+		{"index past the nested array", []string{"list", "0", "1"}},
+		{"key of a scalar", []string{"c", "d"}},
+		{"key of a string", []string{"s", "x"}},
+		{"index of a scalar", []string{"c", "0"}},
+		{"index of a string", []string{"s", "0"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jsonparser.GetInt(json, tt.fields...)
+			assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, err)
+		})
+	}
+}
+
+func TestFindAll_NumberEndsDocument(t *testing.T) {
+	for _, json := range []string{`42`, ` -1.5e3`} {
+		err := jsonparser.FindAll([]byte(json), "a", func(path []jsonparser.PathSegment, value []byte) error {
+			return nil
+		})
+		assert.NoError(t, err, json)
+
+		_, _, err = jsonparser.FindFirst([]byte(json), "a")
+		assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, err, json)
+	}
+}
+
+func TestGetInt_BracketsInStrings(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   string
+		fields []string
+	}{
+		{"brace in a nested object", `{"a": {"s": "}"}, "b": 5}`, []string{"b"}},
+		{"bracket in a nested array", `[["]"], 5]`, []string{"1"}},
+		{"escaped quote before a brace", `[{"s": "\"}"}, 5]`, []string{"1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := jsonparser.GetInt([]byte(tt.json), tt.fields...)
+			assert.NoError(t, err)
+			assert.Equal(t, 5, value)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package jsonparser_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var orderJson = `{
+	"id": "ord-1",
+	"customer": {"name": "Ada", "tier": 2},
+	"lines": [
+		{"sku": "a\"1", "qty": 3},
+		{"sku": "b2", "qty": 10}
+	],
+	"total": 42.5,
+	"paid": true,
+	"note": null
+}`
+
+// feedChunks feeds input in chunks of size bytes and records every callback as "path=value"
+func feedChunks(t *testing.T, input string, size int, subscriptions ...[]string) ([]string, error) {
+	t.Helper()
+
+	var events []string
+	p := jsonparser.NewPushParser()
+	for _, fields := range subscriptions {
+		p.Subscribe(func(path []jsonparser.PathSegment, value []byte) error {
+			events = append(events, strings.Join(pathString(path), ".")+"="+string(value))
+			return nil
+		}, fields...)
+	}
+
+	for start := 0; start < len(input); start += size {
+		end := min(start+size, len(input))
+		if err := p.Feed([]byte(input[start:end])); err != nil {
+			return events, err
+		}
+	}
+
+	return events, p.Close()
+}
+
+func TestPushParser(t *testing.T) {
+	expected := []string{
+		`id="ord-1"`,
+		`customer.name="Ada"`,
+		`lines.0.sku="a\"1"`,
+		`lines.1.sku="b2"`,
+		`total=42.5`,
+		`paid=true`,
+	}
+
+	// the result does not depend on how the input is split
+	for _, size := range []int{1, 2, 3, 7, 64, len(orderJson)} {
+		t.Run(fmt.Sprintf("chunks of %d", size), func(t *testing.T) {
+			events, err := feedChunks(t, orderJson, size,
+				[]string{"id"},
+				[]string{"customer", "name"},
+				[]string{"lines", "*", "sku"},
+				[]string{"total"},
+				[]string{"paid"},
+			)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, events)
+		})
+	}
+}
+
+func TestPushParser_Containers(t *testing.T) {
+	events, err := feedChunks(t, orderJson, 5,
+		[]string{"customer"},
+		[]string{"lines", "1"},
+		[]string{"lines", "1", "qty"},
+		[]string{"note"},
+	)
+	assert.NoError(t, err)
+
+	// nested subscriptions fire innermost first, as soon as each value ends
+	assert.Equal(t, []string{
+		`customer={"name": "Ada", "tier": 2}`,
+		`lines.1.qty=10`,
+		`lines.1={"sku": "b2", "qty": 10}`,
+		`note=null`,
+	}, events)
+
+	events, err = feedChunks(t, ` [1, {"a": []}] `, 1, []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`=[1, {"a": []}]`}, events)
+
+	// a number ending the document is complete only at Close
+	events, err = feedChunks(t, `12`, 1, []string{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{`=12`}, events)
+}
+
+func TestPushParser_FiresEarly(t *testing.T) {
+	var got string
+	p := jsonparser.NewPushParser()
+	p.Subscribe(func(path []jsonparser.PathSegment, value []byte) error {
+		got = string(value)
+		return nil
+	}, "status")
+
+	assert.NoError(t, p.Feed([]byte(`{"status": "ok", "data": [1, 2`)))
+	assert.Equal(t, `"ok"`, got, "the callback runs before the rest of the body arrives")
+
+	assert.NoError(t, p.Feed([]byte(`, 3]}`)))
+	assert.NoError(t, p.Close())
+}
+
+func TestPushParser_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{"truncated", `{"a": [1, 2`, jsonparser.ERROR_INVALID_JSON},
+		{"truncated string", `{"a": "text`, jsonparser.ERROR_INVALID_JSON},
+		{"missing colon", `{"a" 1}`, jsonparser.ERROR_COLON_NOT_FOUND},
+		{"trailing comma", `[1, 2,]`, jsonparser.ERROR_INVALID_JSON},
+		{"mismatched", `[1}`, jsonparser.ERROR_INVALID_JSON},
+		{"bad literal", `[tru]`, jsonparser.ERROR_INVALID_JSON},
+		{"bad number", `[1.]`, jsonparser.ERROR_INVALID_FLOAT},
+		{"two documents", `{} {}`, jsonparser.ERROR_INVALID_JSON},
+		{"empty", ``, jsonparser.ERROR_INVALID_JSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := feedChunks(t, tt.input, 2)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+
+	p := jsonparser.NewPushParser()
+	stop := fmt.Errorf("stop")
+	p.Subscribe(func(path []jsonparser.PathSegment, value []byte) error {
+		return stop
+	}, "a")
+
+	assert.Equal(t, stop, p.Feed([]byte(`{"a": 1, "b": 2}`)))
+	assert.Equal(t, 7, p.Offset(), "the number ends at the comma")
+	assert.Equal(t, stop, p.Feed([]byte(`more`)), "errors are sticky")
+
+	p.Reset()
+	assert.Equal(t, stop, p.Feed([]byte(`{"a": 1}`)), "subscriptions survive Reset")
+}
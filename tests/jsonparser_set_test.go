@@ -0,0 +1,190 @@
+package jsonparser_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var configJson = `{
+    "name": "api",
+    "server": {
+        "host": "localhost",
+        "ports": [80, 443]
+    },
+    "tags": []
+}`
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		fields   []string
+		expected string
+	}{
+		{
+			"replace string", `"web"`, []string{"name"},
+			`{
+    "name": "web",
+    "server": {
+        "host": "localhost",
+        "ports": [80, 443]
+    },
+    "tags": []
+}`,
+		},
+		{
+			"replace container", `{"a": 1}`, []string{"server"},
+			`{
+    "name": "api",
+    "server": {"a": 1},
+    "tags": []
+}`,
+		},
+		{
+			"replace element", `8080`, []string{"server", "ports", "1"},
+			`{
+    "name": "api",
+    "server": {
+        "host": "localhost",
+        "ports": [80, 8080]
+    },
+    "tags": []
+}`,
+		},
+		{
+			"new key", `true`, []string{"server", "tls"},
+			`{
+    "name": "api",
+    "server": {
+        "host": "localhost",
+        "ports": [80, 443],
+        "tls": true
+    },
+    "tags": []
+}`,
+		},
+		{
+			"append", `8443`, []string{"server", "ports", "2"},
+			`{
+    "name": "api",
+    "server": {
+        "host": "localhost",
+        "ports": [80, 443, 8443]
+    },
+    "tags": []
+}`,
+		},
+		{
+			"append to empty array", `"beta"`, []string{"tags", "0"},
+			`{
+    "name": "api",
+    "server": {
+        "host": "localhost",
+        "ports": [80, 443]
+    },
+    "tags": ["beta"]
+}`,
+		},
+		{
+			"intermediate containers", `"info"`, []string{"logging", "outputs", "0", "level"},
+			`{
+    "name": "api",
+    "server": {
+        "host": "localhost",
+        "ports": [80, 443]
+    },
+    "tags": [],
+    "logging": {"outputs": [{"level": "info"}]}
+}`,
+		},
+		{"whole document", ` [1] `, nil, `[1]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := jsonparser.Set([]byte(configJson), []byte(tt.value), tt.fields...)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(res))
+			assert.True(t, json.Valid(res))
+		})
+	}
+}
+
+func TestSet_Compact(t *testing.T) {
+	res, err := jsonparser.Set([]byte(`{"a":1,"b":{}}`), []byte(`2`), "b", "c")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1,"b":{"c":2}}`, string(res))
+
+	res, err = jsonparser.Set(res, []byte(`[]`), "d")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1,"b":{"c":2},"d":[]}`, string(res))
+
+	input := []byte(`{"a": 1}`)
+	_, err = jsonparser.Set(input, []byte(`2`), "a")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a": 1}`, string(input), "the input is not modified")
+}
+
+func TestSet_EscapedKeys(t *testing.T) {
+	res, err := jsonparser.Set([]byte(`{}`), []byte(`1`), `a"b\c`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a\"b\\c":1}`, string(res))
+	assert.True(t, json.Valid(res))
+
+	// the key is matched escaped, it is replaced and not created again
+	res, err = jsonparser.Set(res, []byte(`2`), `a"b\c`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a\"b\\c":2}`, string(res))
+
+	res, err = jsonparser.Set([]byte(`{}`), []byte(`1`), "x\ny", `"`, "0")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"x\ny":{"\"":[1]}}`, string(res))
+	assert.True(t, json.Valid(res))
+
+	res, err = jsonparser.Delete(res, "x\ny", `"`)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"x\ny":{}}`, string(res))
+}
+
+func TestSet_NumericKeys(t *testing.T) {
+	res, err := jsonparser.Set([]byte(`{"1": "a", "list": [0]}`), []byte(`"b"`), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"1": "b", "list": [0]}`, string(res))
+
+	res, err = jsonparser.Set(res, []byte(`2`), "0")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"1": "b", "list": [0], "0": 2}`, string(res), "a numeric key is created in an object")
+
+	res, err = jsonparser.Set(res, []byte(`1`), "list", "1")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"1": "b", "list": [0, 1], "0": 2}`, string(res))
+}
+
+func TestSet_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   string
+		value  string
+		fields []string
+		err    error
+	}{
+		{"invalid value", `{}`, `{"a": `, []string{"a"}, jsonparser.ERROR_INVALID_JSON},
+		{"two values", `{}`, `1 2`, []string{"a"}, jsonparser.ERROR_INVALID_JSON},
+		{"index past the end", `[1, 2]`, `3`, []string{"3"}, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"new array past the start", `{}`, `3`, []string{"a", "1"}, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"key in array", `[1]`, `3`, []string{"a"}, jsonparser.ERROR_TYPE_MISMATCH},
+		{"through a scalar", `{"a": 1}`, `3`, []string{"a", "b"}, jsonparser.ERROR_TYPE_MISMATCH},
+		{"empty document", ``, `3`, []string{"a"}, jsonparser.ERROR_INVALID_JSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := jsonparser.Set([]byte(tt.json), []byte(tt.value), tt.fields...)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
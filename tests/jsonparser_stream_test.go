@@ -0,0 +1,213 @@
+package jsonparser_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var exportJson = `{
+	"meta": {"skip": ["]", "}", {"deep": [1, 2, "\"]"]}], "count": 3},
+	"items": [
+		{"id": 1, "name": "first"},
+		"second",
+		3,
+		[4, 5],
+		null
+	],
+	"after": true
+}`
+
+// readAll returns every element of the stream as a string
+func readAll(t *testing.T, d *jsonparser.StreamDecoder) []string {
+	t.Helper()
+
+	var res []string
+	for {
+		raw, err := d.Next()
+		if err == io.EOF {
+			return res
+		}
+		if !assert.NoError(t, err) {
+			return res
+		}
+		res = append(res, string(raw))
+	}
+}
+
+func TestStreamDecoder(t *testing.T) {
+	expected := []string{`{"id": 1, "name": "first"}`, `"second"`, `3`, `[4, 5]`, `null`}
+
+	d := jsonparser.NewStreamDecoder(strings.NewReader(exportJson), "items")
+	assert.Equal(t, expected, readAll(t, d))
+	assert.Equal(t, 5, d.Index())
+
+	// the same elements when the reader returns one byte at a time
+	d = jsonparser.NewStreamDecoder(iotest.OneByteReader(strings.NewReader(exportJson)), "items")
+	assert.Equal(t, expected, readAll(t, d))
+
+	d = jsonparser.NewStreamDecoder(strings.NewReader(exportJson), "meta", "skip", "2", "deep")
+	assert.Equal(t, []string{`1`, `2`, `"\"]"`}, readAll(t, d))
+
+	d = jsonparser.NewStreamDecoder(strings.NewReader(` [ 1 , 2 ] `))
+	assert.Equal(t, []string{`1`, `2`}, readAll(t, d))
+
+	d = jsonparser.NewStreamDecoder(strings.NewReader(`[]`))
+	assert.Empty(t, readAll(t, d))
+}
+
+func TestStreamDecoder_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   string
+		fields []string
+		err    error
+	}{
+		{"missing key", exportJson, []string{"missing"}, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"missing index", exportJson, []string{"meta", "skip", "9"}, jsonparser.ERROR_FIELD_NOT_FOUND},
+		{"not an array", exportJson, []string{"meta", "count"}, jsonparser.ERROR_TYPE_MISMATCH},
+		{"truncated", `{"items": [1, 2`, []string{"items"}, jsonparser.ERROR_INVALID_JSON},
+		{"missing comma", `[1 2]`, nil, jsonparser.ERROR_INVALID_ARRAY},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := jsonparser.NewStreamDecoder(strings.NewReader(tt.json), tt.fields...)
+
+			var err error
+			for err == nil {
+				_, err = d.Next()
+			}
+
+			assert.Equal(t, tt.err, err)
+
+			_, again := d.Next()
+			assert.Equal(t, err, again, "errors are sticky")
+		})
+	}
+}
+
+func TestStreamDecoder_FailsEarly(t *testing.T) {
+	// the rest of the input is never read: the invalid value is before the end of the buffered data
+	errRead := errors.New("read past the invalid value")
+
+	tests := []struct {
+		prefix string
+		fields []string
+	}{
+		{`[1, x, 2, `, nil},
+		{`[1, trux, 2, `, nil},
+		{`{"items": [1, ], "more": [`, []string{"items"}},
+	}
+
+	for _, tt := range tests {
+		r := io.MultiReader(strings.NewReader(tt.prefix), iotest.ErrReader(errRead))
+		d := jsonparser.NewStreamDecoder(r, tt.fields...)
+
+		var err error
+		for err == nil {
+			_, err = d.Next()
+		}
+		assert.NotEqual(t, errRead, err, tt.prefix)
+	}
+}
+
+func TestDecodeNext(t *testing.T) {
+	d := jsonparser.NewStreamDecoder(strings.NewReader(`{"ids": [10, 20, 30]}`), "ids")
+
+	var ids []int
+	for {
+		var id int
+		err := jsonparser.DecodeNext(d, &id)
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		ids = append(ids, id)
+	}
+	assert.Equal(t, []int{10, 20, 30}, ids)
+
+	d = jsonparser.NewStreamDecoder(strings.NewReader(`{"names": ["a", "b"]}`), "names")
+	var names []string
+	for {
+		var name string
+		if err := jsonparser.DecodeNext(d, &name); err != nil {
+			assert.Equal(t, io.EOF, err)
+			break
+		}
+		names = append(names, name)
+	}
+	assert.Equal(t, []string{"a", "b"}, names)
+}
+
+func TestStreamDecoder_LargeElement(t *testing.T) {
+	// larger than the initial buffer, it grows to hold the element
+	large := `"` + strings.Repeat("x", 200*1024) + `"`
+	d := jsonparser.NewStreamDecoder(strings.NewReader(`[1, ` + large + `, 2]`))
+
+	assert.Equal(t, []string{`1`, large, `2`}, readAll(t, d))
+}
+
+var (
+	generatedFirst = `[{"id": 0, "padding": "` + strings.Repeat("p", 64) + `"}`
+	generatedNext  = `, {"id": 1, "padding": "` + strings.Repeat("p", 64) + `"}`
+)
+
+// generator produces [{"id": 0, ...}, {"id": 1, ...}, ...] without holding the document
+type generator struct {
+	count, total int
+	pending      bytes.Buffer
+}
+
+func (g *generator) Read(p []byte) (int, error) {
+	for g.pending.Len() < len(p) && g.count <= g.total {
+		switch {
+		case g.count == 0:
+			g.pending.WriteString(generatedFirst)
+		case g.count == g.total:
+			g.pending.WriteString(`]`)
+		default:
+			g.pending.WriteString(generatedNext)
+		}
+		g.count++
+	}
+
+	if g.pending.Len() == 0 {
+		return 0, io.EOF
+	}
+	return g.pending.Read(p)
+}
+
+func TestStreamDecoder_BoundedMemory(t *testing.T) {
+	const elements = 200_000 // about 20MB
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+
+	d := jsonparser.NewStreamDecoder(&generator{total: elements})
+	count := 0
+	for {
+		_, err := d.Next()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			break
+		}
+		count++
+	}
+
+	runtime.ReadMemStats(&after)
+
+	assert.Equal(t, elements, count)
+	assert.Less(t, after.TotalAlloc-before.TotalAlloc, uint64(4*1024*1024), "the decoder should not buffer the document")
+}
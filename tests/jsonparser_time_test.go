@@ -0,0 +1,147 @@
+package jsonparser_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var eventJson = []byte(`{
+	"created": "2024-03-15T10:30:00Z",
+	"updated": "2024-03-15T12:30:00.5+02:00",
+	"day": "15/03/2024",
+	"unix": 1710498600,
+	"unixFraction": 1710498600.25,
+	"unixMilli": 1710498600123,
+	"unixNano": 1710498600123456789,
+	"timeout": "1h30m",
+	"retry": "PT5M",
+	"window": "P1DT2H30M",
+	"weeks": "P2W",
+	"fraction": "PT1.5S",
+	"negative": "-PT10S",
+	"months": "P1M",
+	"nanos": 1500000000,
+	"flag": true
+}`)
+
+func TestGetTime(t *testing.T) {
+	expected := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		layout   string
+		field    string
+		expected time.Time
+	}{
+		{"rfc3339", "", "created", expected},
+		{"rfc3339 with offset", time.RFC3339Nano, "updated", expected.Add(500 * time.Millisecond)},
+		{"custom layout", "02/01/2006", "day", time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"unix default", "", "unix", expected},
+		{"unix seconds", jsonparser.TIME_UNIX, "unix", expected},
+		{"unix fraction", jsonparser.TIME_UNIX, "unixFraction", expected.Add(250 * time.Millisecond)},
+		{"unix milli", jsonparser.TIME_UNIX_MILLI, "unixMilli", expected.Add(123 * time.Millisecond)},
+		{"unix nano", jsonparser.TIME_UNIX_NANO, "unixNano", expected.Add(123456789 * time.Nanosecond)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := jsonparser.GetTime(eventJson, tt.layout, tt.field)
+			assert.NoError(t, err, "Error getting %s", tt.field)
+			assert.True(t, tt.expected.Equal(result), "%s should equal %s, got %s", tt.field, tt.expected, result)
+		})
+	}
+
+	_, err := jsonparser.GetTime(eventJson, "", "day")
+	assert.Equal(t, jsonparser.ERROR_INVALID_TIME, err)
+
+	_, err = jsonparser.GetTime(eventJson, "", "flag")
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+
+	_, err = jsonparser.GetTime(eventJson, "2006-01-02", "unix")
+	assert.Equal(t, jsonparser.ERROR_ARGUMENTS, err)
+}
+
+func TestGetDuration(t *testing.T) {
+	tests := []struct {
+		field    string
+		expected time.Duration
+	}{
+		{"timeout", 90 * time.Minute},
+		{"retry", 5 * time.Minute},
+		{"window", 26*time.Hour + 30*time.Minute},
+		{"weeks", 14 * 24 * time.Hour},
+		{"fraction", 1500 * time.Millisecond},
+		{"negative", -10 * time.Second},
+		{"nanos", 1500 * time.Millisecond},
+	}
+
+	for _, tt := range tests {
+		result, err := jsonparser.GetDuration(eventJson, tt.field)
+		assert.NoError(t, err, "Error getting %s", tt.field)
+		assert.Equal(t, tt.expected, result, "%s should equal %s", tt.field, tt.expected)
+	}
+
+	_, err := jsonparser.GetDuration(eventJson, "months")
+	assert.Equal(t, jsonparser.ERROR_INVALID_DURATION, err)
+
+	_, err = jsonparser.GetDuration(eventJson, "created")
+	assert.Equal(t, jsonparser.ERROR_INVALID_DURATION, err)
+}
+
+func TestGetDuration_ISO(t *testing.T) {
+	tests := []struct {
+		duration string
+		expected time.Duration
+	}{
+		{"P1W2DT3H4M5S", 9*24*time.Hour + 3*time.Hour + 4*time.Minute + 5*time.Second},
+		{"PT0,25S", 250 * time.Millisecond},
+		{"PT1H0.5M", time.Hour + 30*time.Second},
+		{"PT2562047H47M16.854775807S", math.MaxInt64},
+		{"-PT2562047H47M16.854775808S", math.MinInt64},
+		{"PT0.9999999999S", 999999999 * time.Nanosecond},
+		{"PT0.000000001S", time.Nanosecond},
+		{"P0.333333333333333333W", 201599999999999 * time.Nanosecond},
+		{"PT2562047H47M16.8547758079S", math.MaxInt64},
+		{"PT1.0000000000000000000000001S", time.Second},
+	}
+
+	for _, tt := range tests {
+		result, err := jsonparser.GetDuration([]byte(`{"d": "`+tt.duration+`"}`), "d")
+		assert.NoError(t, err, tt.duration)
+		assert.Equal(t, tt.expected, result, tt.duration)
+	}
+
+	invalid := []string{
+		"P", "PT", "P1DT", "PT5S5M", "PT5M5M", "P1D2W", "PT1.5H5M",
+		"PT.5S", "PT5.S", "P1H", "PT1D", "PT2562047H47M16.854775808S", "P15251W",
+	}
+	for _, duration := range invalid {
+		_, err := jsonparser.GetDuration([]byte(`{"d": "`+duration+`"}`), "d")
+		assert.Equal(t, jsonparser.ERROR_INVALID_DURATION, err, duration)
+	}
+}
+
+func TestGet_TimeTargets(t *testing.T) {
+	var created time.Time
+	_, err := jsonparser.Get(&created, eventJson, "created")
+	assert.NoError(t, err)
+	assert.True(t, time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC).Equal(created))
+
+	var timeout time.Duration
+	_, err = jsonparser.Get(&timeout, eventJson, "window")
+	assert.NoError(t, err)
+	assert.Equal(t, 26*time.Hour+30*time.Minute, timeout)
+
+	retry, err := jsonparser.GetOr(eventJson, time.Second, "missing")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second, retry)
+
+	optional, err := jsonparser.GetOptional[time.Time](eventJson, "unix")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1710498600), optional.Value.Unix())
+}
@@ -0,0 +1,159 @@
+package jsonparser_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+// tokens reads the whole document and returns the kinds and values
+func tokens(t *testing.T, json string) ([]jsonparser.TokenKind, []string) {
+	t.Helper()
+
+	var kinds []jsonparser.TokenKind
+	var values []string
+
+	tokenizer := jsonparser.NewTokenizer([]byte(json))
+	for {
+		token, err := tokenizer.Next()
+		if err == io.EOF {
+			return kinds, values
+		}
+		if !assert.NoError(t, err, "Error tokenizing %s at %d", json, tokenizer.Offset()) {
+			return kinds, values
+		}
+
+		assert.NotEmpty(t, json[token.Start:token.End])
+		kinds = append(kinds, token.Kind)
+		values = append(values, string(token.Value))
+	}
+}
+
+func TestTokenizer(t *testing.T) {
+	kinds, values := tokens(t, ` {"name": "John", "tags": ["a\"b", "c\\"], "age": -1.5e3, "ok": true, "no": false, "nil": null, "empty": {}} `)
+
+	assert.Equal(t, []jsonparser.TokenKind{
+		jsonparser.TOKEN_OBJECT_START,
+		jsonparser.TOKEN_KEY, jsonparser.TOKEN_STRING,
+		jsonparser.TOKEN_KEY, jsonparser.TOKEN_ARRAY_START, jsonparser.TOKEN_STRING, jsonparser.TOKEN_STRING, jsonparser.TOKEN_ARRAY_END,
+		jsonparser.TOKEN_KEY, jsonparser.TOKEN_NUMBER,
+		jsonparser.TOKEN_KEY, jsonparser.TOKEN_TRUE,
+		jsonparser.TOKEN_KEY, jsonparser.TOKEN_FALSE,
+		jsonparser.TOKEN_KEY, jsonparser.TOKEN_NULL,
+		jsonparser.TOKEN_KEY, jsonparser.TOKEN_OBJECT_START, jsonparser.TOKEN_OBJECT_END,
+		jsonparser.TOKEN_OBJECT_END,
+	}, kinds)
+
+	assert.Equal(t, []string{
+		"{", "name", "John", "tags", "[", `a\"b`, `c\\`, "]", "age", "-1.5e3",
+		"ok", "true", "no", "false", "nil", "null", "empty", "{", "}", "}",
+	}, values)
+
+	kinds, values = tokens(t, `42`)
+	assert.Equal(t, []jsonparser.TokenKind{jsonparser.TOKEN_NUMBER}, kinds)
+	assert.Equal(t, []string{"42"}, values)
+
+	kinds, _ = tokens(t, `[[], [[]]]`)
+	assert.Len(t, kinds, 8)
+}
+
+func TestTokenizer_Offsets(t *testing.T) {
+	json := []byte(`{"key": "value", "list": [1]}`)
+	tokenizer := jsonparser.NewTokenizer(json)
+
+	expected := []string{`{`, `"key"`, `"value"`, `"list"`, `[`, `1`, `]`, `}`}
+	for _, raw := range expected {
+		token, err := tokenizer.Next()
+		assert.NoError(t, err)
+		assert.Equal(t, raw, string(json[token.Start:token.End]))
+	}
+
+	_, err := tokenizer.Next()
+	assert.Equal(t, io.EOF, err)
+	assert.Equal(t, 0, tokenizer.Depth())
+}
+
+func TestTokenizer_Invalid(t *testing.T) {
+	tests := []struct {
+		json   string
+		err    error
+		offset int
+	}{
+		{``, jsonparser.ERROR_INVALID_JSON, 0},
+		{`{"a" 1}`, jsonparser.ERROR_COLON_NOT_FOUND, 5},
+		{`{"a": 1,}`, jsonparser.ERROR_INVALID_JSON, 8},
+		{`[1,]`, jsonparser.ERROR_INVALID_JSON, 3},
+		{`[1 2]`, jsonparser.ERROR_INVALID_JSON, 3},
+		{`[1}`, jsonparser.ERROR_INVALID_JSON, 2},
+		{`{1: 2}`, jsonparser.ERROR_INVALID_JSON, 1},
+		{`[01]`, jsonparser.ERROR_INVALID_FLOAT, 1},
+		{`[tru]`, jsonparser.ERROR_INVALID_BOOLEAN, 1},
+		{`[nul]`, jsonparser.ERROR_INVALID_NULL, 1},
+		{`["open]`, jsonparser.ERROR_INVALID_JSON, 1},
+		{`[1`, jsonparser.ERROR_INVALID_JSON, 2},
+		{`{} {}`, jsonparser.ERROR_INVALID_JSON, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.json, func(t *testing.T) {
+			tokenizer := jsonparser.NewTokenizer([]byte(tt.json))
+
+			var err error
+			for err == nil {
+				_, err = tokenizer.Next()
+			}
+
+			assert.Equal(t, tt.err, err)
+			assert.Equal(t, tt.offset, tokenizer.Offset())
+		})
+	}
+}
+
+func TestTokenizer_NoAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		tokenizer := jsonparser.NewTokenizer(primitivesTestJson)
+		for {
+			if _, err := tokenizer.Next(); err != nil {
+				break
+			}
+		}
+	})
+	assert.LessOrEqual(t, allocs, 3.0, "only the tokenizer and its container stack allocate")
+}
+
+func TestTokenizer_Skip(t *testing.T) {
+	tokenizer := jsonparser.NewTokenizer([]byte(`{"skip": {"a": "}"}, "list": [1, [2], 3], "last": 4}`))
+
+	next := func() string {
+		token, err := tokenizer.Next()
+		assert.NoError(t, err)
+		return string(token.Value)
+	}
+
+	assert.Equal(t, "{", next())
+	assert.Equal(t, "skip", next())
+	assert.NoError(t, tokenizer.Skip(), "skips the member value")
+
+	assert.Equal(t, "list", next())
+	assert.Equal(t, "[", next())
+	assert.Equal(t, "1", next())
+	assert.NoError(t, tokenizer.Skip(), "skips the next element")
+	assert.Equal(t, "3", next())
+	assert.Equal(t, jsonparser.ERROR_ARGUMENTS, tokenizer.Skip(), "there is no next element")
+	assert.Equal(t, "]", next())
+
+	assert.Equal(t, "last", next())
+	assert.Equal(t, "4", next())
+	assert.Equal(t, jsonparser.ERROR_ARGUMENTS, tokenizer.Skip(), "a key is next, not a value")
+	assert.Equal(t, "}", next())
+
+	tokenizer = jsonparser.NewTokenizer([]byte(`[[1, 2], 3]`))
+	next()
+	next()
+	assert.NoError(t, tokenizer.Skip(), "skips the rest of the array just opened")
+	assert.Equal(t, 1, tokenizer.Depth())
+	assert.Equal(t, "3", next())
+}
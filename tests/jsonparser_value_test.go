@@ -0,0 +1,184 @@
+package jsonparser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+var profileJson = []byte(`{
+	"name": "John",
+	"age": 30,
+	"active": true,
+	"score": 85.5,
+	"tags": ["dev", "golang", {"lang": "go"}],
+	"profile": {"city": "NYC", "zip": 10001, "manager": null}
+}`)
+
+func TestParse(t *testing.T) {
+	root, err := jsonparser.Parse(profileJson)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonparser.TYPE_OBJECT, root.Kind())
+
+	tests := []struct {
+		json     string
+		expected jsonparser.ValueType
+	}{
+		{`  42  `, jsonparser.TYPE_NUMBER},
+		{`"text"`, jsonparser.TYPE_STRING},
+		{`[1, 2]`, jsonparser.TYPE_ARRAY},
+		{`false`, jsonparser.TYPE_BOOLEAN},
+		{`null`, jsonparser.TYPE_NULL},
+	}
+
+	for _, tt := range tests {
+		v, err := jsonparser.Parse([]byte(tt.json))
+		assert.NoError(t, err, "Error parsing %s", tt.json)
+		assert.Equal(t, tt.expected, v.Kind(), "kind of %s", tt.json)
+	}
+
+	for _, invalid := range []string{``, `   `, `{"a": 1} {"b": 2}`, `[1, 2`} {
+		_, err := jsonparser.Parse([]byte(invalid))
+		assert.Error(t, err, "%q should not parse", invalid)
+	}
+}
+
+func TestValue_Get(t *testing.T) {
+	root, err := jsonparser.Parse(profileJson)
+	assert.NoError(t, err)
+
+	profile, err := root.Get("profile")
+	assert.NoError(t, err)
+	assert.Equal(t, jsonparser.TYPE_OBJECT, profile.Kind())
+	assert.Equal(t, `{"city": "NYC", "zip": 10001, "manager": null}`, string(profile.Raw()))
+
+	city, err := profile.Get("city")
+	assert.NoError(t, err)
+	assert.Equal(t, "NYC", city.String())
+	assert.Equal(t, `"NYC"`, string(city.Raw()))
+
+	zip, err := profile.Get("zip")
+	assert.NoError(t, err)
+	zipInt, err := zip.Int()
+	assert.NoError(t, err)
+	assert.Equal(t, 10001, zipInt)
+
+	// the narrowed window does not see the siblings of its parent
+	_, err = profile.Get("name")
+	assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, err)
+
+	lang, err := root.Get("tags", "2", "lang")
+	assert.NoError(t, err)
+	assert.Equal(t, "go", lang.String())
+
+	same, err := profile.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, profile, same)
+
+	manager, err := profile.Get("manager")
+	assert.NoError(t, err)
+	assert.Equal(t, jsonparser.TYPE_NULL, manager.Kind())
+
+	_, err = jsonparser.Value{}.Get("name")
+	assert.Error(t, err)
+}
+
+func TestValue_Index(t *testing.T) {
+	root, _ := jsonparser.Parse(profileJson)
+	tags, err := root.Get("tags")
+	assert.NoError(t, err)
+
+	second, err := tags.Index(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "golang", second.String())
+
+	third, err := tags.Index(2)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonparser.TYPE_OBJECT, third.Kind())
+
+	_, err = tags.Index(3)
+	assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, err)
+
+	_, err = tags.Index(-1)
+	assert.Equal(t, jsonparser.ERROR_ARGUMENTS, err)
+
+	_, err = root.Index(0)
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+}
+
+func TestValue_Each(t *testing.T) {
+	root, _ := jsonparser.Parse(profileJson)
+	profile, _ := root.Get("profile")
+
+	var keys []string
+	var kinds []jsonparser.ValueType
+	for segment, v := range profile.Each() {
+		keys = append(keys, segment.Key)
+		kinds = append(kinds, v.Kind())
+	}
+	assert.Equal(t, []string{"city", "zip", "manager"}, keys)
+	assert.Equal(t, []jsonparser.ValueType{jsonparser.TYPE_STRING, jsonparser.TYPE_NUMBER, jsonparser.TYPE_NULL}, kinds)
+
+	tags, _ := root.Get("tags")
+	var indexes []int
+	for segment := range tags.Each() {
+		indexes = append(indexes, segment.Index)
+		if segment.Index == 1 {
+			break
+		}
+	}
+	assert.Equal(t, []int{0, 1}, indexes)
+
+	age, _ := root.Get("age")
+	for range age.Each() {
+		t.Error("a number has no elements")
+	}
+}
+
+func TestValue_Scalars(t *testing.T) {
+	root, _ := jsonparser.Parse(profileJson)
+
+	age, _ := root.Get("age")
+	age64, err := age.Int64()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(30), age64)
+	assert.Equal(t, "30", age.String())
+
+	score, _ := root.Get("score")
+	scoreFloat, err := score.Float64()
+	assert.NoError(t, err)
+	assert.Equal(t, 85.5, scoreFloat)
+
+	active, _ := root.Get("active")
+	activeBool, err := active.Bool()
+	assert.NoError(t, err)
+	assert.True(t, activeBool)
+
+	name, _ := root.Get("name")
+	_, err = name.Int()
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+	_, err = name.Bool()
+	assert.Equal(t, jsonparser.ERROR_TYPE_MISMATCH, err)
+}
+
+func BenchmarkValue_DeepNavigation(b *testing.B) {
+	b.ReportAllocs()
+
+	root, err := jsonparser.Parse(primitivesTestJson)
+	if err != nil {
+		b.Fatal(err)
+	}
+	level3, err := root.Get("nested", "level2", "level3")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := level3.Get("extremelyDeepString"); err != nil {
+			b.Error(err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package jsonparser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+func TestGetStringView(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+	}{
+		{"simple", []string{"name"}},
+		{"nested", []string{"profile", "city"}},
+		{"array element", []string{"tags", "1"}},
+		{"escaped", []string{"quote"}},
+	}
+
+	json := []byte(`{"name": "John", "tags": ["dev", "golang"], "profile": {"city": "NYC"}, "quote": "say \"hi\""}`)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := jsonparser.GetString(json, tt.fields...)
+			assert.NoError(t, err)
+
+			view, err := jsonparser.GetStringView(json, tt.fields...)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, view)
+		})
+	}
+
+	_, err := jsonparser.GetStringView(json, "missing")
+	assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, err)
+
+	_, err = jsonparser.GetStringView(json)
+	assert.Equal(t, jsonparser.ERROR_ARGUMENTS, err)
+}
+
+func TestGetStringView_AliasesInput(t *testing.T) {
+	json := []byte(`{"name": "John"}`)
+
+	view, err := jsonparser.GetStringView(json, "name")
+	assert.NoError(t, err)
+	assert.Equal(t, "John", view)
+
+	// the view follows the buffer, this is the lifetime contract
+	copy(json[10:], "Jane")
+	assert.Equal(t, "Jane", view)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		view, _ = jsonparser.GetStringView(primitivesTestJson, "stringValue")
+	})
+	assert.Equal(t, 0.0, allocs)
+}
+
+func TestAppendString(t *testing.T) {
+	json := []byte(`{"first": "John", "last": "Doe", "age": 30}`)
+
+	buf := make([]byte, 0, 32)
+	buf, err := jsonparser.AppendString(buf, json, "first")
+	assert.NoError(t, err)
+	buf = append(buf, ' ')
+	buf, err = jsonparser.AppendString(buf, json, "last")
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", string(buf))
+
+	// the result does not alias the input
+	copy(json[11:], "Jane")
+	assert.Equal(t, "John Doe", string(buf))
+
+	result, err := jsonparser.AppendString(buf, json, "missing")
+	assert.Equal(t, jsonparser.ERROR_FIELD_NOT_FOUND, err)
+	assert.Equal(t, "John Doe", string(result))
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = jsonparser.AppendString(buf[:0], primitivesTestJson, "stringValue")
+	})
+	assert.Equal(t, 0.0, allocs)
+}
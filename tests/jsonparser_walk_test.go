@@ -0,0 +1,133 @@
+package jsonparser_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/muccarini/jsonparser"
+)
+
+// recorder writes one line per event with the path of the event
+type recorder struct {
+	events []string
+	skip   string // path where SKIP_SUBTREE is returned
+	stop   string // path where STOP_WALK is returned
+}
+
+func (r *recorder) record(event string, path []jsonparser.PathSegment) error {
+	p := strings.Join(pathString(path), ".")
+	r.events = append(r.events, event+" "+p)
+
+	switch {
+	case r.skip != "" && p == r.skip:
+		return jsonparser.SKIP_SUBTREE
+	case r.stop != "" && p == r.stop:
+		return jsonparser.STOP_WALK
+	}
+	return nil
+}
+
+func (r *recorder) OnObjectStart(path []jsonparser.PathSegment) error {
+	return r.record("{", path)
+}
+
+func (r *recorder) OnArrayStart(path []jsonparser.PathSegment) error {
+	return r.record("[", path)
+}
+
+func (r *recorder) OnKey(path []jsonparser.PathSegment, key string) error {
+	return r.record("key:"+key, path)
+}
+
+func (r *recorder) OnValue(path []jsonparser.PathSegment, kind jsonparser.ValueType, raw []byte) error {
+	return r.record(fmt.Sprintf("%s:%s", kind, raw), path)
+}
+
+func (r *recorder) OnEnd(path []jsonparser.PathSegment) error {
+	return r.record("end", path)
+}
+
+var walkJson = []byte(`{"id": 7, "tags": ["a", {"x": null}], "meta": {"ok": true, "brace": "}]"}}`)
+
+func TestWalk(t *testing.T) {
+	r := &recorder{}
+	assert.NoError(t, jsonparser.Walk(walkJson, r))
+
+	assert.Equal(t, []string{
+		"{ ",
+		"key:id id",
+		"number:7 id",
+		"key:tags tags",
+		"[ tags",
+		"string:a tags.0",
+		"{ tags.1",
+		"key:x tags.1.x",
+		"null:null tags.1.x",
+		"end tags.1",
+		"end tags",
+		"key:meta meta",
+		"{ meta",
+		"key:ok meta.ok",
+		"boolean:true meta.ok",
+		"key:brace meta.brace",
+		"string:}] meta.brace",
+		"end meta",
+		"end ",
+	}, r.events)
+}
+
+func TestWalk_Skip(t *testing.T) {
+	// from a container start the container is skipped, OnEnd included
+	r := &recorder{skip: "tags"}
+	assert.NoError(t, jsonparser.Walk(walkJson, r))
+	assert.NotContains(t, strings.Join(r.events, "\n"), "tags.")
+	assert.NotContains(t, r.events, "end tags")
+	assert.Contains(t, r.events, "boolean:true meta.ok")
+
+	// the container skip jumps over brackets inside strings
+	r = &recorder{skip: "meta"}
+	assert.NoError(t, jsonparser.Walk(walkJson, r))
+	assert.Equal(t, "end ", r.events[len(r.events)-1])
+	assert.NotContains(t, r.events, "key:ok meta.ok")
+
+	// from a key the member value is skipped
+	r = &recorder{skip: "id"}
+	assert.NoError(t, jsonparser.Walk(walkJson, r))
+	assert.Equal(t, []string{"{ ", "key:id id", "key:tags tags"}, r.events[:3])
+
+	// from a scalar nothing changes
+	r = &recorder{skip: "tags.0"}
+	assert.NoError(t, jsonparser.Walk(walkJson, r))
+	assert.Len(t, r.events, 19)
+}
+
+func TestWalk_Stop(t *testing.T) {
+	r := &recorder{stop: "tags.1.x"}
+	assert.NoError(t, jsonparser.Walk(walkJson, r))
+	assert.Equal(t, "key:x tags.1.x", r.events[len(r.events)-1])
+
+	err := jsonparser.Walk([]byte(`{"a": [1, 2}`), &recorder{})
+	assert.Equal(t, jsonparser.ERROR_INVALID_JSON, err)
+}
+
+// countingHandler only implements the events it needs
+type countingHandler struct {
+	jsonparser.BaseHandler
+	numbers int
+}
+
+func (h *countingHandler) OnValue(path []jsonparser.PathSegment, kind jsonparser.ValueType, raw []byte) error {
+	if kind == jsonparser.TYPE_NUMBER {
+		h.numbers++
+	}
+	return nil
+}
+
+func TestWalk_BaseHandler(t *testing.T) {
+	h := &countingHandler{}
+	assert.NoError(t, jsonparser.Walk(arrayTestJson, h))
+	assert.Greater(t, h.numbers, 0)
+}
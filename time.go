@@ -0,0 +1,294 @@
+package jsonparser
+
+import (
+	"math"
+	"math/bits"
+	"strings"
+	"time"
+)
+
+// Layouts of GetTime for number values, they are not valid time.Parse layouts
+const (
+	TIME_UNIX       = "unix"       // seconds since the epoch, fractions allowed
+	TIME_UNIX_MILLI = "unix_milli" // milliseconds since the epoch, fractions allowed
+	TIME_UNIX_NANO  = "unix_nano"  // nanoseconds since the epoch
+)
+
+// durationUnit maps the ISO 8601 designators to their length, M is the minute
+var durationUnit = map[byte]time.Duration{
+	'W': 7 * 24 * time.Hour,
+	'D': 24 * time.Hour,
+	'H': time.Hour,
+	'M': time.Minute,
+	'S': time.Second,
+}
+
+// API
+
+// GetTime returns the time at the field path.
+// Strings are parsed with layout, time.RFC3339 when empty.
+// Numbers are read as TIME_UNIX, TIME_UNIX_MILLI or TIME_UNIX_NANO, TIME_UNIX when the layout is empty.
+// Times from numbers are in UTC
+func GetTime(json []byte, layout string, fields ...string) (time.Time, error) {
+	if len(json) == 0 {
+		return time.Time{}, ERROR_INVALID_JSON
+	}
+
+	if len(fields) == 0 {
+		return time.Time{}, ERROR_ARGUMENTS
+	}
+
+	pos, err := findValuePosWs(json, fields...)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	valueSlice, err := extractValue(json, pos)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return parseTime(valueType(json, pos), valueSlice, layout)
+}
+
+// GetDuration returns the duration at the field path.
+// Strings are either go durations like "1h30m" or ISO 8601 durations like "PT5M" or "P1DT2H",
+// years and months are rejected since their length varies.
+// Numbers are nanoseconds, as encoding/json writes time.Duration
+func GetDuration(json []byte, fields ...string) (time.Duration, error) {
+	if len(json) == 0 {
+		return 0, ERROR_INVALID_JSON
+	}
+
+	if len(fields) == 0 {
+		return 0, ERROR_ARGUMENTS
+	}
+
+	pos, err := findValuePosWs(json, fields...)
+	if err != nil {
+		return 0, err
+	}
+
+	valueSlice, err := extractValue(json, pos)
+	if err != nil {
+		return 0, err
+	}
+
+	return parseDuration(valueType(json, pos), valueSlice)
+}
+
+// INTERNAL
+
+// decodeTime stores a value into the time.Time and time.Duration targets
+func decodeTime(value any, kind ValueType, slice []byte) (bool, error) {
+	switch target := value.(type) {
+	case *time.Time:
+		res, err := parseTime(kind, slice, "")
+		if err != nil {
+			return true, err
+		}
+		*target = res
+		return true, nil
+
+	case *time.Duration:
+		res, err := parseDuration(kind, slice)
+		if err != nil {
+			return true, err
+		}
+		*target = res
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func parseTime(kind ValueType, slice []byte, layout string) (time.Time, error) {
+	switch kind {
+	case TYPE_STRING:
+		if layout == "" {
+			layout = time.RFC3339
+		}
+
+		res, err := time.Parse(layout, string(slice))
+		if err != nil {
+			return time.Time{}, ERROR_INVALID_TIME
+		}
+		return res, nil
+
+	case TYPE_NUMBER:
+		// the nanoseconds are the mantissa of the number with 9, 6 or 0 fractional digits
+		var scale int
+		switch layout {
+		case "", TIME_UNIX:
+			scale = 9
+		case TIME_UNIX_MILLI:
+			scale = 6
+		case TIME_UNIX_NANO:
+			scale = 0
+		default:
+			return time.Time{}, ERROR_ARGUMENTS
+		}
+
+		number, err := ParseDecimal(slice)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		if number.scale > scale {
+			number = number.Round(scale)
+		}
+		nanos, err := number.rescale(scale)
+		if err != nil {
+			return time.Time{}, ERROR_INVALID_TIME
+		}
+
+		return time.Unix(0, nanos.mantissa).UTC(), nil
+	}
+
+	return time.Time{}, ERROR_TYPE_MISMATCH
+}
+
+func parseDuration(kind ValueType, slice []byte) (time.Duration, error) {
+	switch kind {
+	case TYPE_STRING:
+		if res, ok := parseISODuration(slice); ok {
+			return res, nil
+		}
+
+		res, err := time.ParseDuration(string(slice))
+		if err != nil {
+			return 0, ERROR_INVALID_DURATION
+		}
+		return res, nil
+
+	case TYPE_NUMBER:
+		res, err := ParseInt64(slice)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(res), nil
+	}
+
+	return 0, ERROR_TYPE_MISMATCH
+}
+
+// parseISODuration parses the ISO 8601 durations P[nW][nD][T[nH][nM][nS]],
+// the last component can have a fraction, truncated to nanoseconds. ok is false if the syntax is not ISO 8601
+// or the duration overflows
+func parseISODuration(slice []byte) (time.Duration, bool) {
+	pos := 0
+	neg := false
+	if pos < len(slice) && (slice[pos] == '-' || slice[pos] == '+') {
+		neg = slice[pos] == '-'
+		pos++
+	}
+
+	if pos >= len(slice) || slice[pos] != 'P' {
+		return 0, false
+	}
+	pos++
+
+	limit := uint64(math.MaxInt64)
+	if neg {
+		limit++
+	}
+
+	// the designators still allowed, in their order: M is months in the date part and minutes in the time part
+	order := "WD"
+	inTime := false
+	components := 0
+	fraction := false
+	var total uint64 // nanoseconds
+
+	for pos < len(slice) {
+		if slice[pos] == 'T' {
+			pos++
+			if inTime || pos >= len(slice) {
+				return 0, false // a second or an empty time part
+			}
+			inTime, order = true, "HMS"
+			continue
+		}
+
+		if fraction {
+			return 0, false // only the last component can have a fraction
+		}
+
+		start := pos
+		for pos < len(slice) && slice[pos] >= '0' && slice[pos] <= '9' {
+			pos++
+		}
+		integer := slice[start:pos]
+
+		var decimals []byte
+		if pos < len(slice) && (slice[pos] == '.' || slice[pos] == ',') {
+			pos++
+			start = pos
+			for pos < len(slice) && slice[pos] >= '0' && slice[pos] <= '9' {
+				pos++
+			}
+			decimals = slice[start:pos]
+			if len(decimals) == 0 {
+				return 0, false
+			}
+			fraction = true
+		}
+
+		if len(integer) == 0 || pos >= len(slice) {
+			return 0, false
+		}
+
+		// a designator out of order, repeated or of the other part is not found
+		designator := slice[pos]
+		pos++
+		i := strings.IndexByte(order, designator)
+		if i < 0 {
+			return 0, false
+		}
+		order = order[i+1:]
+		unit := uint64(durationUnit[designator])
+
+		var n uint64
+		for _, c := range integer {
+			digit := uint64(c - '0')
+			if n > (limit/unit-digit)/10 {
+				return 0, false
+			}
+			n = n*10 + digit
+		}
+		n *= unit
+
+		if fraction {
+			// the digits past the 18th weigh less than a thousandth of a nanosecond even for weeks
+			if len(decimals) > 18 {
+				decimals = decimals[:18]
+			}
+			var frac uint64
+			for _, c := range decimals {
+				frac = frac*10 + uint64(c-'0')
+			}
+			// the fraction of the unit truncated to nanoseconds, frac*unit needs 128 bits
+			hi, lo := bits.Mul64(frac, unit)
+			nanos, _ := bits.Div64(hi, lo, uint64(int64pow10[len(decimals)]))
+			if nanos > limit-n {
+				return 0, false
+			}
+			n += nanos
+		}
+
+		if n > limit-total {
+			return 0, false
+		}
+		total += n
+		components++
+	}
+
+	if components == 0 {
+		return 0, false
+	}
+
+	if neg {
+		return -time.Duration(total), true // -(1<<63) wraps to itself, math.MinInt64
+	}
+	return time.Duration(total), true
+}
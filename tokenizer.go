@@ -0,0 +1,306 @@
+package jsonparser
+
+import "io"
+
+// TokenKind is the kind of a Token
+type TokenKind int
+
+const (
+	TOKEN_INVALID TokenKind = iota
+	TOKEN_OBJECT_START
+	TOKEN_OBJECT_END
+	TOKEN_ARRAY_START
+	TOKEN_ARRAY_END
+	TOKEN_KEY
+	TOKEN_STRING
+	TOKEN_NUMBER
+	TOKEN_TRUE
+	TOKEN_FALSE
+	TOKEN_NULL
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case TOKEN_OBJECT_START:
+		return "object start"
+	case TOKEN_OBJECT_END:
+		return "object end"
+	case TOKEN_ARRAY_START:
+		return "array start"
+	case TOKEN_ARRAY_END:
+		return "array end"
+	case TOKEN_KEY:
+		return "key"
+	case TOKEN_STRING:
+		return "string"
+	case TOKEN_NUMBER:
+		return "number"
+	case TOKEN_TRUE:
+		return "true"
+	case TOKEN_FALSE:
+		return "false"
+	case TOKEN_NULL:
+		return "null"
+	}
+	return "invalid"
+}
+
+// Token is a lexical element of the document. [Start, End) is its position in the input,
+// quotes included for keys and strings. Value aliases the input: the content without
+// quotes for keys and strings, as GetString returns it, the literal for the other kinds
+type Token struct {
+	Kind  TokenKind
+	Start int
+	End   int
+	Value []byte
+}
+
+// Tokenizer reads the tokens of a document one at a time. It checks the structure
+// (nesting, commas and colons) as it goes and does not allocate per token
+type Tokenizer struct {
+	json   []byte
+	pos    int
+	stack  []byte // '{' and '[' of the open containers
+	opened int    // position of the last container start
+	expect expectation
+}
+
+type expectation int
+
+const (
+	expectValue      expectation = iota // a value, at the start or after ':' and ',' in arrays
+	expectFirstValue                    // a value or ']', after '['
+	expectKey                           // a key, after ',' in objects
+	expectFirstKey                      // a key or '}', after '{'
+	expectColon                         // ':', after a key read byte by byte
+	expectSeparator                     // ',' or the end of the container, after a value
+	expectEOF                           // the document is complete
+)
+
+// API
+
+func NewTokenizer(json []byte) *Tokenizer {
+	return &Tokenizer{json: json}
+}
+
+// Next returns the next token, io.EOF after the end of the document.
+// On malformed input the error is returned and Offset tells where
+func (t *Tokenizer) Next() (Token, error) {
+	t.pos = skipWhitespace(t.json, t.pos)
+
+	if t.expect == expectEOF {
+		if t.pos < len(t.json) {
+			return Token{}, ERROR_INVALID_JSON
+		}
+		return Token{}, io.EOF
+	}
+
+	if t.pos >= len(t.json) {
+		return Token{}, ERROR_INVALID_JSON
+	}
+
+	c := t.json[t.pos]
+
+	switch t.expect {
+	case expectSeparator:
+		switch {
+		case c == ',' && t.top() == '{':
+			t.expect = expectKey
+		case c == ',' && t.top() == '[':
+			t.expect = expectValue
+		case c == '}' && t.top() == '{', c == ']' && t.top() == '[':
+			return t.closeContainer(), nil
+		default:
+			return Token{}, ERROR_INVALID_JSON
+		}
+		t.pos++
+		return t.Next()
+
+	case expectFirstKey, expectKey:
+		if c == '}' && t.expect == expectFirstKey {
+			return t.closeContainer(), nil
+		}
+		if c != '"' {
+			return Token{}, ERROR_INVALID_JSON
+		}
+		return t.key()
+
+	case expectFirstValue:
+		if c == ']' {
+			return t.closeContainer(), nil
+		}
+	}
+
+	return t.value(c)
+}
+
+// Skip skips the next value without reading its tokens. Right after TOKEN_OBJECT_START or
+// TOKEN_ARRAY_START it skips the rest of that container, its end token included,
+// between two array elements it skips the next element.
+// Skipped containers are only checked to be balanced
+func (t *Tokenizer) Skip() error {
+	var start int
+
+	// between two elements of an array the next value is after the comma
+	if t.expect == expectSeparator && t.top() == '[' {
+		pos := skipWhitespace(t.json, t.pos)
+		if pos < len(t.json) && t.json[pos] == ',' {
+			t.pos = pos + 1
+			t.expect = expectValue
+		}
+	}
+
+	switch t.expect {
+	case expectFirstKey, expectFirstValue:
+		start = t.opened
+		t.stack = t.stack[:len(t.stack)-1]
+	case expectValue:
+		start = skipWhitespace(t.json, t.pos)
+		if start >= len(t.json) {
+			t.pos = start
+			return ERROR_INVALID_JSON
+		}
+	default:
+		return ERROR_ARGUMENTS
+	}
+
+	var end int
+	var err error
+	switch t.json[start] {
+	case '{':
+		end, err = skipObject(t.json, start)
+	case '[':
+		end, err = skipMatrix(t.json, start)
+	default:
+		end, err = valueEnd(t.json, start)
+	}
+	if err != nil {
+		t.pos = start
+		return err
+	}
+
+	t.pos = end
+	t.valueDone()
+	return nil
+}
+
+// Offset returns the position in the input where the next token is searched,
+// after an error it is the position of the malformed input
+func (t *Tokenizer) Offset() int {
+	return t.pos
+}
+
+// Depth returns the number of open containers
+func (t *Tokenizer) Depth() int {
+	return len(t.stack)
+}
+
+// INTERNAL
+
+func (t *Tokenizer) top() byte {
+	if len(t.stack) == 0 {
+		return 0
+	}
+	return t.stack[len(t.stack)-1]
+}
+
+// key reads a key and the colon after it
+func (t *Tokenizer) key() (Token, error) {
+	start := t.pos
+
+	key, err := extractString(t.json, start)
+	if err != nil {
+		return Token{}, err
+	}
+	end := start + len(key) + 2
+
+	colon := skipWhitespace(t.json, end)
+	if colon >= len(t.json) || t.json[colon] != ':' {
+		t.pos = colon
+		return Token{}, ERROR_COLON_NOT_FOUND
+	}
+
+	t.pos = colon + 1
+	t.expect = expectValue
+
+	return Token{Kind: TOKEN_KEY, Start: start, End: end, Value: key}, nil
+}
+
+func (t *Tokenizer) value(c byte) (Token, error) {
+	start := t.pos
+	token := Token{Start: start}
+
+	switch c {
+	case '{', '[':
+		t.stack = append(t.stack, c)
+		t.opened = start
+		t.pos++
+
+		token.Kind, t.expect = TOKEN_OBJECT_START, expectFirstKey
+		if c == '[' {
+			token.Kind, t.expect = TOKEN_ARRAY_START, expectFirstValue
+		}
+		token.End, token.Value = t.pos, t.json[start:t.pos]
+		return token, nil
+
+	case '"':
+		str, err := extractString(t.json, start)
+		if err != nil {
+			return Token{}, err
+		}
+		token.Kind, token.End, token.Value = TOKEN_STRING, start+len(str)+2, str
+
+	case 't', 'f':
+		literal, err := extractBoolean(t.json, start)
+		if err != nil {
+			return Token{}, err
+		}
+		token.Kind, token.End, token.Value = TOKEN_TRUE, start+len(literal), literal
+		if c == 'f' {
+			token.Kind = TOKEN_FALSE
+		}
+
+	case 'n':
+		literal, err := extractNull(t.json, start)
+		if err != nil {
+			return Token{}, err
+		}
+		token.Kind, token.End, token.Value = TOKEN_NULL, start+len(literal), literal
+
+	default:
+		number, err := extractNumber(t.json, start)
+		if err != nil || len(number) == 0 {
+			return Token{}, ERROR_INVALID_JSON
+		}
+		if !isValidNumber(number) {
+			return Token{}, ERROR_INVALID_FLOAT
+		}
+		token.Kind, token.End, token.Value = TOKEN_NUMBER, start+len(number), number
+	}
+
+	t.pos = token.End
+	t.valueDone()
+	return token, nil
+}
+
+// closeContainer reads the closing brace or bracket at the current position
+func (t *Tokenizer) closeContainer() Token {
+	token := Token{Kind: TOKEN_OBJECT_END, Start: t.pos, End: t.pos + 1, Value: t.json[t.pos : t.pos+1]}
+	if t.json[t.pos] == ']' {
+		token.Kind = TOKEN_ARRAY_END
+	}
+
+	t.stack = t.stack[:len(t.stack)-1]
+	t.pos++
+	t.valueDone()
+
+	return token
+}
+
+func (t *Tokenizer) valueDone() {
+	if len(t.stack) == 0 {
+		t.expect = expectEOF
+	} else {
+		t.expect = expectSeparator
+	}
+}
@@ -0,0 +1,155 @@
+package jsonparser
+
+import "iter"
+
+// Value is a lazy handle on a JSON value: raw is the slice of the document holding the value,
+// quotes included for strings. Nothing is decoded until asked, and every navigation step
+// only scans the window of the current value. The zero Value is TYPE_UNKNOWN
+type Value struct {
+	raw  []byte
+	kind ValueType
+}
+
+// API
+
+// Parse returns the Value of the whole document. Only its bounds are checked,
+// the content is validated while navigating
+func Parse(json []byte) (Value, error) {
+	pos := skipWhitespace(json, 0)
+	if pos >= len(json) {
+		return Value{}, ERROR_INVALID_JSON
+	}
+
+	end, err := valueEnd(json, pos)
+	if err != nil {
+		return Value{}, err
+	}
+
+	if skipWhitespace(json, end) != len(json) {
+		return Value{}, ERROR_INVALID_JSON
+	}
+
+	return valueAt(json, pos, end), nil
+}
+
+// Get returns the value at the field path relative to v, no fields returns v
+func (v Value) Get(fields ...string) (Value, error) {
+	if len(fields) == 0 {
+		return v, nil
+	}
+
+	pos, err := findValuePosWs(v.raw, fields...)
+	if err != nil {
+		return Value{}, err
+	}
+
+	end, err := valueEnd(v.raw, pos)
+	if err != nil {
+		return Value{}, err
+	}
+
+	return valueAt(v.raw, pos, end), nil
+}
+
+// Index returns the i-th element of an array
+func (v Value) Index(i int) (Value, error) {
+	if v.kind != TYPE_ARRAY {
+		return Value{}, ERROR_TYPE_MISMATCH
+	}
+
+	if i < 0 {
+		return Value{}, ERROR_ARGUMENTS
+	}
+
+	var res Value
+	_, err := arrayEach(v.raw, 0, func(index, start, end int) error {
+		if index == i {
+			res = valueAt(v.raw, start, end)
+			return errStop
+		}
+		return nil
+	})
+
+	switch {
+	case err == errStop:
+		return res, nil
+	case err != nil:
+		return Value{}, err
+	}
+	return Value{}, ERROR_FIELD_NOT_FOUND
+}
+
+// Each returns an iterator over the members of an object or the elements of an array,
+// in document order. Nothing is yielded for other kinds or when the value is malformed
+func (v Value) Each() iter.Seq2[PathSegment, Value] {
+	return func(yield func(PathSegment, Value) bool) {
+		switch v.kind {
+		case TYPE_OBJECT:
+			objectEach(v.raw, 0, func(key []byte, start, end int) error {
+				if !yield(PathSegment{Key: string(key), Index: -1}, valueAt(v.raw, start, end)) {
+					return errStop
+				}
+				return nil
+			})
+		case TYPE_ARRAY:
+			arrayEach(v.raw, 0, func(index, start, end int) error {
+				if !yield(PathSegment{Index: index}, valueAt(v.raw, start, end)) {
+					return errStop
+				}
+				return nil
+			})
+		}
+	}
+}
+
+// Kind returns the JSON type of v
+func (v Value) Kind() ValueType {
+	return v.kind
+}
+
+// Raw returns the JSON text of v, it aliases the parsed document
+func (v Value) Raw() []byte {
+	return v.raw
+}
+
+// String returns the content of a string as GetString does, the JSON text for other kinds
+func (v Value) String() string {
+	if v.kind == TYPE_STRING {
+		return string(v.raw[1 : len(v.raw)-1])
+	}
+	return string(v.raw)
+}
+
+func (v Value) Int() (int, error) {
+	if v.kind != TYPE_NUMBER {
+		return 0, ERROR_TYPE_MISMATCH
+	}
+	return ParseInt(v.raw)
+}
+
+func (v Value) Int64() (int64, error) {
+	if v.kind != TYPE_NUMBER {
+		return 0, ERROR_TYPE_MISMATCH
+	}
+	return ParseInt64(v.raw)
+}
+
+func (v Value) Float64() (float64, error) {
+	if v.kind != TYPE_NUMBER {
+		return 0, ERROR_TYPE_MISMATCH
+	}
+	return ParseFloat64(v.raw)
+}
+
+func (v Value) Bool() (bool, error) {
+	if v.kind != TYPE_BOOLEAN {
+		return false, ERROR_TYPE_MISMATCH
+	}
+	return ParseBool(v.raw)
+}
+
+// INTERNAL
+
+func valueAt(json []byte, start, end int) Value {
+	return Value{raw: json[start:end], kind: valueType(json, start)}
+}
@@ -0,0 +1,52 @@
+package jsonparser
+
+import "unsafe"
+
+// API
+
+// GetStringView returns the same string as GetString without copying it: the result aliases json.
+//
+// This is unsafe. The string is only valid as long as json is neither modified nor reused,
+// writing to json afterwards silently changes the string, which breaks the immutability
+// that the rest of the program assumes. Use it on buffers that outlive the string, and copy
+// with strings.Clone whatever is kept longer. Escapes are kept as written, like GetString does,
+// so no unescaping is ever needed and the view never allocates
+func GetStringView(json []byte, fields ...string) (string, error) {
+	valueSlice, err := lookupString(json, fields...)
+	if err != nil || len(valueSlice) == 0 {
+		return "", err
+	}
+
+	return unsafe.String(unsafe.SliceData(valueSlice), len(valueSlice)), nil
+}
+
+// AppendString appends the string at the field path to dst, it is the safe way to avoid
+// the allocation of GetString by reusing a buffer. On errors dst is returned unchanged
+func AppendString(dst []byte, json []byte, fields ...string) ([]byte, error) {
+	valueSlice, err := lookupString(json, fields...)
+	if err != nil {
+		return dst, err
+	}
+
+	return append(dst, valueSlice...), nil
+}
+
+// INTERNAL
+
+// lookupString returns the slice of the value at the field path as GetString reads it
+func lookupString(json []byte, fields ...string) ([]byte, error) {
+	if len(json) == 0 {
+		return nil, ERROR_INVALID_JSON
+	}
+
+	if len(fields) == 0 {
+		return nil, ERROR_ARGUMENTS
+	}
+
+	pos, err := findValuePos(json, fields...)
+	if err != nil {
+		return nil, err
+	}
+
+	return extractValue(json, pos)
+}
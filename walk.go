@@ -0,0 +1,163 @@
+package jsonparser
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+var (
+	// SKIP_SUBTREE is returned by a Handler callback to skip the value it was called for:
+	// from OnObjectStart and OnArrayStart the container is skipped and OnEnd is not called,
+	// from OnKey the member value is skipped. From the other callbacks it is ignored
+	SKIP_SUBTREE = fmt.Errorf("skip subtree")
+
+	// STOP_WALK is returned by a Handler callback to end Walk early without an error
+	STOP_WALK = fmt.Errorf("stop walk")
+)
+
+// Handler receives the events of Walk. The path of each event is the path of the value
+// the event is about, empty for the document itself. The path slice and its keys alias
+// internal buffers and json, they are only valid during the callback.
+// Returning an error other than SKIP_SUBTREE or STOP_WALK stops the walk with that error
+type Handler interface {
+	OnObjectStart(path []PathSegment) error
+	OnArrayStart(path []PathSegment) error
+	// OnKey is called before the value of an object member, the last segment of path is key
+	OnKey(path []PathSegment, key string) error
+	// OnValue is called for strings, numbers, booleans and null,
+	// raw is the value as Token.Value holds it
+	OnValue(path []PathSegment, kind ValueType, raw []byte) error
+	// OnEnd is called at the end of an object or an array
+	OnEnd(path []PathSegment) error
+}
+
+// BaseHandler implements every Handler callback doing nothing,
+// embed it to implement only the events of interest
+type BaseHandler struct{}
+
+func (BaseHandler) OnObjectStart(path []PathSegment) error                       { return nil }
+func (BaseHandler) OnArrayStart(path []PathSegment) error                        { return nil }
+func (BaseHandler) OnKey(path []PathSegment, key string) error                   { return nil }
+func (BaseHandler) OnValue(path []PathSegment, kind ValueType, raw []byte) error { return nil }
+func (BaseHandler) OnEnd(path []PathSegment) error                               { return nil }
+
+// API
+
+// Walk reads the whole document once and calls handler for every event, in document order
+func Walk(json []byte, handler Handler) error {
+	w := walker{
+		tokenizer: Tokenizer{json: json},
+		path:      make([]PathSegment, 0, 16),
+	}
+
+	err := w.walk(handler)
+	if err == STOP_WALK {
+		return nil
+	}
+	return err
+}
+
+// INTERNAL
+
+type walker struct {
+	tokenizer Tokenizer
+	path      []PathSegment
+	counts    []int // next element index of each open container, -1 for objects
+}
+
+func (w *walker) walk(handler Handler) error {
+	for {
+		token, err := w.tokenizer.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch token.Kind {
+		case TOKEN_KEY:
+			key := unsafe.String(unsafe.SliceData(token.Value), len(token.Value))
+			w.path = append(w.path, PathSegment{Key: key, Index: -1})
+
+			err = handler.OnKey(w.path, key)
+			if err == SKIP_SUBTREE {
+				if err := w.tokenizer.Skip(); err != nil {
+					return err
+				}
+				w.leave()
+				continue
+			}
+
+		case TOKEN_OBJECT_START, TOKEN_ARRAY_START:
+			w.enter()
+
+			count := -1
+			if token.Kind == TOKEN_OBJECT_START {
+				err = handler.OnObjectStart(w.path)
+			} else {
+				count = 0
+				err = handler.OnArrayStart(w.path)
+			}
+
+			if err == SKIP_SUBTREE {
+				if err := w.tokenizer.Skip(); err != nil {
+					return err
+				}
+				w.leave()
+				continue
+			}
+			w.counts = append(w.counts, count)
+
+		case TOKEN_OBJECT_END, TOKEN_ARRAY_END:
+			w.counts = w.counts[:len(w.counts)-1]
+			err = handler.OnEnd(w.path)
+			w.leave()
+
+		default:
+			w.enter()
+			err = handler.OnValue(w.path, tokenType(token.Kind), token.Value)
+			w.leave()
+		}
+
+		if err != nil && err != SKIP_SUBTREE {
+			return err
+		}
+	}
+}
+
+// enter adds the index segment of a value inside an array, object members got theirs from the key
+func (w *walker) enter() {
+	if len(w.counts) == 0 || w.counts[len(w.counts)-1] < 0 {
+		return
+	}
+
+	w.path = append(w.path, PathSegment{Index: w.counts[len(w.counts)-1]})
+	w.counts[len(w.counts)-1]++
+}
+
+// leave removes the segment of a completed value
+func (w *walker) leave() {
+	if len(w.counts) > 0 {
+		w.path = w.path[:len(w.path)-1]
+	}
+}
+
+func tokenType(kind TokenKind) ValueType {
+	switch kind {
+	case TOKEN_STRING:
+		return TYPE_STRING
+	case TOKEN_NUMBER:
+		return TYPE_NUMBER
+	case TOKEN_TRUE, TOKEN_FALSE:
+		return TYPE_BOOLEAN
+	case TOKEN_NULL:
+		return TYPE_NULL
+	case TOKEN_OBJECT_START:
+		return TYPE_OBJECT
+	case TOKEN_ARRAY_START:
+		return TYPE_ARRAY
+	}
+	return TYPE_UNKNOWN
+}